@@ -0,0 +1,119 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// AddShortURLResponse 创建短链接口服务器响应
+type AddShortURLResponse struct {
+	ErrorMessage
+	Data *ShortURLData `json:"Data,omitempty"` // 短链信息
+}
+
+// GetData 获取短链信息
+func (a *AddShortURLResponse) GetData() *ShortURLData {
+	if a != nil && a.Data != nil {
+		return a.Data
+	}
+	return nil
+}
+
+// String 序列化成JSON字符串
+func (a AddShortURLResponse) String() string {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// AddShortURLRequest 创建短链接口请求
+type AddShortURLRequest struct {
+	Request *Request
+}
+
+// SetSourceURL 设置原始链接地址 必须, 不超过1000个字符
+func (a *AddShortURLRequest) SetSourceURL(sourceURL string) {
+	if a != nil && a.Request != nil {
+		a.Request.Put("SourceUrl", sourceURL)
+	}
+}
+
+// GetSourceURL 获取原始链接地址
+func (a *AddShortURLRequest) GetSourceURL() string {
+	if a != nil && a.Request != nil {
+		return a.Request.Get("SourceUrl")
+	}
+	return ""
+}
+
+// SetShortURLName 设置短链服务名称 必须, 不超过13个字符
+func (a *AddShortURLRequest) SetShortURLName(shortURLName string) {
+	if a != nil && a.Request != nil {
+		a.Request.Put("ShortUrlName", shortURLName)
+	}
+}
+
+// GetShortURLName 获取短链服务名称
+func (a *AddShortURLRequest) GetShortURLName() string {
+	if a != nil && a.Request != nil {
+		return a.Request.Get("ShortUrlName")
+	}
+	return ""
+}
+
+// SetEffectiveDays 设置短链服务使用有效期 必须, 取值30、60或90天
+func (a *AddShortURLRequest) SetEffectiveDays(effectiveDays int) {
+	if a != nil && a.Request != nil {
+		a.Request.Put("EffectiveDays", strconv.Itoa(effectiveDays))
+	}
+}
+
+// GetEffectiveDays 获取短链服务使用有效期
+func (a *AddShortURLRequest) GetEffectiveDays() int {
+	if a != nil && a.Request != nil {
+		n, _ := strconv.Atoi(a.Request.Get("EffectiveDays"))
+		return n
+	}
+	return 0
+}
+
+// DoActionWithException 发起HTTP请求
+func (a *AddShortURLRequest) DoActionWithException() (resp *AddShortURLResponse, err error) {
+	if a != nil && a.Request != nil {
+		resp := &AddShortURLResponse{}
+		body, httpCode, err := a.Request.DoPost("AddShortUrl")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("AddShortURLRequest is nil")
+}
+
+// AddShortURL 创建短链接口
+// sourceURL 必填 - 原始链接地址
+// shortURLName 必填 - 短链服务名称
+// effectiveDays 必填 - 短链服务使用有效期, 取值30、60或90天
+func AddShortURL(sourceURL, shortURLName string, effectiveDays int) *AddShortURLRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "AddShortUrl")
+
+	r := &AddShortURLRequest{Request: req}
+	r.SetSourceURL(sourceURL)         // 必填 - 原始链接地址
+	r.SetShortURLName(shortURLName)   // 必填 - 短链服务名称
+	r.SetEffectiveDays(effectiveDays) // 必填 - 短链服务使用有效期
+	return r
+}
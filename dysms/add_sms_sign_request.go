@@ -0,0 +1,131 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// AddSmsSignResponse 申请短信签名接口服务器响应
+type AddSmsSignResponse struct {
+	ErrorMessage
+	SignName *string `json:"SignName,omitempty"` // 签名名称
+}
+
+// GetSignName 获取签名名称
+func (a *AddSmsSignResponse) GetSignName() string {
+	if a != nil && a.SignName != nil {
+		return *a.SignName
+	}
+	return ""
+}
+
+// String 序列化成JSON字符串
+func (a AddSmsSignResponse) String() string {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// AddSmsSignRequest 申请短信签名接口请求
+type AddSmsSignRequest struct {
+	Request *Request
+}
+
+// SetSignName 设置签名名称 必须
+func (a *AddSmsSignRequest) SetSignName(signName string) {
+	if a != nil && a.Request != nil {
+		a.Request.Put("SignName", signName)
+	}
+}
+
+// GetSignName 获取签名名称
+func (a *AddSmsSignRequest) GetSignName() string {
+	if a != nil && a.Request != nil {
+		return a.Request.Get("SignName")
+	}
+	return ""
+}
+
+// SetSignSource 设置签名来源 必须
+func (a *AddSmsSignRequest) SetSignSource(signSource SignSource) {
+	if a != nil && a.Request != nil {
+		a.Request.Put("SignSource", strconv.Itoa(int(signSource)))
+	}
+}
+
+// GetSignSource 获取签名来源
+func (a *AddSmsSignRequest) GetSignSource() SignSource {
+	if a != nil && a.Request != nil {
+		n, _ := strconv.Atoi(a.Request.Get("SignSource"))
+		return SignSource(n)
+	}
+	return 0
+}
+
+// SetRemark 设置短信签名申请说明 必须
+// 请在申请说明中详细描述您的业务使用场景, 申请工信部备案网站的全称或简称请在此处填写域名
+func (a *AddSmsSignRequest) SetRemark(remark string) {
+	if a != nil && a.Request != nil {
+		a.Request.Put("Remark", remark)
+	}
+}
+
+// GetRemark 获取短信签名申请说明
+func (a *AddSmsSignRequest) GetRemark() string {
+	if a != nil && a.Request != nil {
+		return a.Request.Get("Remark")
+	}
+	return ""
+}
+
+// SetSignFileList 设置签名的资质证明文件列表
+func (a *AddSmsSignRequest) SetSignFileList(signFileList []SignFile) {
+	if a != nil && a.Request != nil {
+		putSignFileList(a.Request, signFileList)
+	}
+}
+
+// DoActionWithException 发起HTTP请求
+func (a *AddSmsSignRequest) DoActionWithException() (resp *AddSmsSignResponse, err error) {
+	if a != nil && a.Request != nil {
+		resp := &AddSmsSignResponse{}
+		body, httpCode, err := a.Request.DoPost("AddSmsSign")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("AddSmsSignRequest is nil")
+}
+
+// AddSmsSign 申请短信签名接口
+// signName 必填 - 签名名称
+// signSource 必填 - 签名来源
+// remark 必填 - 短信签名申请说明
+// signFileList 可选 - 签名的资质证明文件列表
+func AddSmsSign(signName string, signSource SignSource, remark string, signFileList ...SignFile) *AddSmsSignRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "AddSmsSign")
+
+	r := &AddSmsSignRequest{Request: req}
+	r.SetSignName(signName)     // 必填 - 签名名称
+	r.SetSignSource(signSource) // 必填 - 签名来源
+	r.SetRemark(remark)         // 必填 - 短信签名申请说明
+	if len(signFileList) > 0 {
+		r.SetSignFileList(signFileList) // 可选 - 签名的资质证明文件列表
+	}
+	return r
+}
@@ -0,0 +1,159 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// BatchSendMessageToGlobeResponse 批量发送国际/港澳台短信接口服务器响应
+type BatchSendMessageToGlobeResponse struct {
+	GlobeErrorMessage
+	MessageIDList *string `json:"MessageIdList,omitempty"` // 短信ID列表, JSON字符串
+	FailedList    *string `json:"FailedList,omitempty"`    // 发送失败的号码列表, JSON字符串
+	To            *string `json:"To,omitempty"`            // 接收号码列表, JSON字符串
+	From          *string `json:"From,omitempty"`          // 发送方标识
+}
+
+// GetMessageIDList 获取短信ID列表
+func (b *BatchSendMessageToGlobeResponse) GetMessageIDList() []string {
+	var list []string
+	if b != nil && b.MessageIDList != nil {
+		json.Unmarshal([]byte(*b.MessageIDList), &list)
+	}
+	return list
+}
+
+// GetFailedList 获取发送失败的号码列表
+func (b *BatchSendMessageToGlobeResponse) GetFailedList() []string {
+	var list []string
+	if b != nil && b.FailedList != nil {
+		json.Unmarshal([]byte(*b.FailedList), &list)
+	}
+	return list
+}
+
+// GetFrom 获取发送方标识
+func (b *BatchSendMessageToGlobeResponse) GetFrom() string {
+	if b != nil && b.From != nil {
+		return *b.From
+	}
+	return ""
+}
+
+// String 序列化成JSON字符串
+func (b BatchSendMessageToGlobeResponse) String() string {
+	body, err := json.Marshal(b)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// BatchSendMessageToGlobeRequest 批量发送国际/港澳台短信接口请求
+type BatchSendMessageToGlobeRequest struct {
+	Request *Request
+}
+
+// SetTo 设置接收号码列表 必须, 格式为国际区号+号码, 如6591234567
+func (b *BatchSendMessageToGlobeRequest) SetTo(to []string) {
+	if b != nil && b.Request != nil {
+		b.Request.Put("To", toJSONString(to))
+	}
+}
+
+// GetTo 获取接收号码列表, JSON字符串
+func (b *BatchSendMessageToGlobeRequest) GetTo() string {
+	if b != nil && b.Request != nil {
+		return b.Request.Get("To")
+	}
+	return ""
+}
+
+// SetFrom 设置发送方标识 可选
+func (b *BatchSendMessageToGlobeRequest) SetFrom(from string) {
+	if b != nil && b.Request != nil {
+		b.Request.Put("From", from)
+	}
+}
+
+// GetFrom 获取发送方标识
+func (b *BatchSendMessageToGlobeRequest) GetFrom() string {
+	if b != nil && b.Request != nil {
+		return b.Request.Get("From")
+	}
+	return ""
+}
+
+// SetMessage 设置短信内容 必须
+func (b *BatchSendMessageToGlobeRequest) SetMessage(message string) {
+	if b != nil && b.Request != nil {
+		b.Request.Put("Message", message)
+	}
+}
+
+// GetMessage 获取短信内容
+func (b *BatchSendMessageToGlobeRequest) GetMessage() string {
+	if b != nil && b.Request != nil {
+		return b.Request.Get("Message")
+	}
+	return ""
+}
+
+// SetTaskID 设置任务ID 可选
+func (b *BatchSendMessageToGlobeRequest) SetTaskID(taskID string) {
+	if b != nil && b.Request != nil {
+		b.Request.Put("TaskId", taskID)
+	}
+}
+
+// GetTaskID 获取任务ID
+func (b *BatchSendMessageToGlobeRequest) GetTaskID() string {
+	if b != nil && b.Request != nil {
+		return b.Request.Get("TaskId")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (b *BatchSendMessageToGlobeRequest) DoActionWithException() (resp *BatchSendMessageToGlobeResponse, err error) {
+	if b != nil && b.Request != nil {
+		resp := &BatchSendMessageToGlobeResponse{}
+		body, httpCode, err := b.Request.DoPost("BatchSendMessageToGlobe")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		return resp, resp.globeError(httpCode)
+	}
+	return nil, errors.New("BatchSendMessageToGlobeRequest is nil")
+}
+
+// BatchSendMessageToGlobe 批量发送国际/港澳台短信接口
+// to 必填 - 接收号码列表, E.164格式, 如+6591234567
+// from 可选 - 发送方标识
+// message 必填 - 短信内容
+func BatchSendMessageToGlobe(to []string, from, message string) (*BatchSendMessageToGlobeRequest, error) {
+	numbers := make([]string, len(to))
+	for i, phoneNumber := range to {
+		n, err := ParseE164PhoneNumber(phoneNumber)
+		if err != nil {
+			return nil, err
+		}
+		numbers[i] = n
+	}
+	req := newGlobeRequset()
+	req.Put("Action", "BatchSendMessageToGlobe")
+
+	r := &BatchSendMessageToGlobeRequest{Request: req}
+	r.SetTo(numbers) // 必填 - 接收号码列表
+	if from != "" {
+		r.SetFrom(from) // 可选 - 发送方标识
+	}
+	r.SetMessage(message) // 必填 - 短信内容
+	return r, nil
+}
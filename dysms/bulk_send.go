@@ -0,0 +1,257 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/phone"
+)
+
+// MaxPhoneNumbersPerRequest SendSms 接口单次请求的号码数量上限
+const MaxPhoneNumbersPerRequest = 1000
+
+// DefaultBulkConcurrency 批量发送时默认的并发请求数
+const DefaultBulkConcurrency = 4
+
+// BulkSendResult 单个号码的发送结果, 同一分片内的号码共享 BizID 和 RequestID
+type BulkSendResult struct {
+	PhoneNumber string // 短信接收号码
+	Chunk       int    // 所在分片序号, 未通过号码校验时为-1
+	BizID       string // 发送回执ID
+	RequestID   string // 请求ID
+	Err         error  // 发送失败原因, 成功时为nil
+}
+
+// BulkSendSmsResponse 批量发送短信的汇总结果
+type BulkSendSmsResponse struct {
+	Results []BulkSendResult // 按输入顺序排列的每个号码的发送结果
+
+	index map[string]int
+}
+
+// Get 获取指定号码的发送结果
+func (b *BulkSendSmsResponse) Get(phoneNumber string) (BulkSendResult, bool) {
+	if b == nil {
+		return BulkSendResult{}, false
+	}
+	i, ok := b.index[phoneNumber]
+	if !ok {
+		return BulkSendResult{}, false
+	}
+	return b.Results[i], true
+}
+
+// Failed 发送失败的号码结果
+func (b *BulkSendSmsResponse) Failed() []BulkSendResult {
+	var failed []BulkSendResult
+	if b != nil {
+		for _, r := range b.Results {
+			if r.Err != nil {
+				failed = append(failed, r)
+			}
+		}
+	}
+	return failed
+}
+
+// BulkSendError 批量发送部分或全部失败, 可遍历 Failed 获取每个失败号码及原因
+type BulkSendError struct {
+	Total  int              // 号码总数
+	Failed []BulkSendResult // 发送失败的号码结果
+}
+
+// Error 错误描述
+func (e *BulkSendError) Error() string {
+	var msgs []string
+	seen := make(map[error]bool)
+	for _, r := range e.Failed {
+		if !seen[r.Err] {
+			seen[r.Err] = true
+			msgs = append(msgs, r.Err.Error())
+		}
+	}
+	return strconv.Itoa(len(e.Failed)) + "/" + strconv.Itoa(e.Total) + " phone numbers failed: " + strings.Join(msgs, "; ")
+}
+
+// BulkSendSmsRequest 批量发送短信请求, 号码数量不受单次请求上限的限制
+type BulkSendSmsRequest struct {
+	businessID    string
+	phoneNumbers  []string
+	signName      string
+	templateCode  string
+	templateParam string
+	outID         string
+	chunkSize     int
+	concurrency   int
+}
+
+// SetOutID 设置外部流水扩展字段, 所有分片使用相同的值
+func (b *BulkSendSmsRequest) SetOutID(outID string) {
+	if b != nil {
+		b.outID = outID
+	}
+}
+
+// SetChunkSize 设置每个分片的号码数量, 取值范围为1~MaxPhoneNumbersPerRequest
+func (b *BulkSendSmsRequest) SetChunkSize(chunkSize int) {
+	if b != nil {
+		b.chunkSize = chunkSize
+	}
+}
+
+// SetConcurrency 设置并发请求数, 默认为 DefaultBulkConcurrency
+func (b *BulkSendSmsRequest) SetConcurrency(concurrency int) {
+	if b != nil {
+		b.concurrency = concurrency
+	}
+}
+
+// chunks 按分片大小切分号码列表
+func (b *BulkSendSmsRequest) chunks(phoneNumbers []string) [][]string {
+	size := b.chunkSize
+	if size <= 0 || size > MaxPhoneNumbersPerRequest {
+		size = MaxPhoneNumbersPerRequest
+	}
+	var chunks [][]string
+	for len(phoneNumbers) > size {
+		chunks = append(chunks, phoneNumbers[:size])
+		phoneNumbers = phoneNumbers[size:]
+	}
+	if len(phoneNumbers) > 0 {
+		chunks = append(chunks, phoneNumbers)
+	}
+	return chunks
+}
+
+// sendSms 发送短信, 业务错误码不为OK时同样返回错误
+func sendSms(r *SendSmsRequest) (*SendSmsResponse, error) {
+	resp, err := r.DoActionWithException()
+	if err != nil {
+		return resp, err
+	}
+	if resp.GetCode() != "OK" {
+		return resp, &resp.ErrorMessage
+	}
+	return resp, nil
+}
+
+// sendChunk 发送一个分片
+func (b *BulkSendSmsRequest) sendChunk(phoneNumbers []string) (*SendSmsResponse, error) {
+	r := SendSms(b.businessID, strings.Join(phoneNumbers, ","), b.signName, b.templateCode, b.templateParam)
+	if b.outID != "" {
+		r.SetOutID(b.outID)
+	}
+	return sendSms(r)
+}
+
+// DoActionWithException 发起批量发送, 单个分片失败不影响其他分片
+// 存在发送失败的号码时返回汇总结果和 *BulkSendError
+func (b *BulkSendSmsRequest) DoActionWithException() (*BulkSendSmsResponse, error) {
+	return b.DoActionWithContext(context.Background())
+}
+
+// DoActionWithContext 发起批量发送, ctx 取消后尚未发送的分片记为失败
+func (b *BulkSendSmsRequest) DoActionWithContext(ctx context.Context) (*BulkSendSmsResponse, error) {
+	if b == nil {
+		return nil, errors.New("BulkSendSmsRequest is nil")
+	}
+	resp := &BulkSendSmsResponse{index: make(map[string]int)}
+	add := func(r BulkSendResult) {
+		if _, ok := resp.index[r.PhoneNumber]; ok {
+			return
+		}
+		resp.index[r.PhoneNumber] = len(resp.Results)
+		resp.Results = append(resp.Results, r)
+	}
+
+	phoneNumbers := b.phoneNumbers
+	if AutoNormalizePhoneNumbers {
+		numbers, rejected := phone.ParseList(phoneNumbers)
+		phoneNumbers = make([]string, len(numbers))
+		for i, n := range numbers {
+			phoneNumbers[i] = n.National
+			if n.International {
+				phoneNumbers[i] = "00" + n.National
+			}
+		}
+		for _, r := range rejected {
+			add(BulkSendResult{PhoneNumber: r.Input, Chunk: -1, Err: r.Reason})
+		}
+	}
+
+	seen := make(map[string]bool, len(phoneNumbers))
+	unique := make([]string, 0, len(phoneNumbers))
+	for _, n := range phoneNumbers {
+		if !seen[n] {
+			seen[n] = true
+			unique = append(unique, n)
+		}
+	}
+	chunks := b.chunks(unique)
+	for i, chunk := range chunks {
+		for _, n := range chunk {
+			add(BulkSendResult{PhoneNumber: n, Chunk: i})
+		}
+	}
+
+	concurrency := b.concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		go func(i int, chunk []string) {
+			defer wg.Done()
+			var r *SendSmsResponse
+			var err error
+			select {
+			case sem <- struct{}{}:
+				if err = ctx.Err(); err == nil {
+					r, err = b.sendChunk(chunk)
+				}
+				<-sem
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, n := range chunk {
+				result := &resp.Results[resp.index[n]]
+				if r != nil {
+					result.BizID = r.GetBizID()
+					result.RequestID = r.GetRequestID()
+				}
+				result.Err = err
+			}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	if failed := resp.Failed(); len(failed) > 0 {
+		return resp, &BulkSendError{Total: len(resp.Results), Failed: failed}
+	}
+	return resp, nil
+}
+
+// BulkSendSms 批量发送短信, 号码列表按 MaxPhoneNumbersPerRequest 切分后并发发送
+// businessID 设置业务请求流水号
+// phoneNumbers 短信发送的号码列表, 数量不限, 重复的号码只发送一次
+// signName 短信签名
+// templateCode 申请的短信模板编码
+// templateParam 短信模板变量参数
+func BulkSendSms(businessID string, phoneNumbers []string, signName, templateCode, templateParam string) *BulkSendSmsRequest {
+	return &BulkSendSmsRequest{
+		businessID:    businessID,
+		phoneNumbers:  phoneNumbers,
+		signName:      signName,
+		templateCode:  templateCode,
+		templateParam: templateParam,
+	}
+}
@@ -0,0 +1,42 @@
+package dysms
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func Test_BulkSendSms(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		numbers := r.URL.Query().Get("PhoneNumbers")
+		if strings.HasPrefix(numbers, "13800000002") {
+			w.WriteHeader(400)
+			fmt.Fprint(w, `{"RequestId":"R-fail","Code":"isv.BUSINESS_LIMIT_CONTROL","Message":"limit"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"RequestId":"R-%d","Code":"OK","Message":"OK","BizId":"%s"}`, n, numbers)
+	}))
+	defer ts.Close()
+	defer setTestEndPoint(ts.URL + "/")()
+
+	numbers := []string{"13800000000", "13800000001", "13800000002", "13800000000", "13800000003"}
+	r := BulkSendSms("1", numbers, "sign", "SMS_1", "")
+	r.SetChunkSize(2)
+	resp, err := r.DoActionWithException()
+	if requests != 2 || len(resp.Results) != 4 {
+		t.Fatal("BulkSendSms chunking failed", requests, resp.Results)
+	}
+	e, ok := err.(*BulkSendError)
+	if !ok || e.Total != 4 || len(e.Failed) != 2 || e.Failed[0].PhoneNumber != "13800000002" || e.Failed[1].RequestID != "R-fail" {
+		t.Fatal("BulkSendSms error failed", err)
+	}
+	result, ok := resp.Get("13800000001")
+	if !ok || result.Err != nil || result.BizID != "13800000000,13800000001" || result.Chunk != 0 {
+		t.Error("BulkSendSms result failed", result)
+	}
+}
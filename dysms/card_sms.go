@@ -0,0 +1,48 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+)
+
+// CardFallbackType 卡片短信不支持时的回落类型
+type CardFallbackType string
+
+// 回落类型取值
+const (
+	CardFallbackSMS        CardFallbackType = "SMS"        // 回落为文本短信
+	CardFallbackDigitalSMS CardFallbackType = "DIGITALSMS" // 回落为数字短信
+	CardFallbackNone       CardFallbackType = "NONE"       // 不回落
+)
+
+// CardObject 卡片短信发送对象
+type CardObject struct {
+	Mobile     string `json:"mobile"`               // 手机号
+	DyncParams string `json:"dyncParams,omitempty"` // 动态参数, JSON字符串
+	CustomURL  string `json:"customUrl,omitempty"`  // 自定义跳转链接
+}
+
+// CardSupportResult 手机号卡片短信支持情况
+type CardSupportResult struct {
+	Mobile  string `json:"mobile"`  // 手机号
+	Support bool   `json:"support"` // 是否支持卡片短信
+}
+
+// CardSmsSendData 卡片短信发送结果
+type CardSmsSendData struct {
+	BizCardID       string `json:"BizCardId"`       // 卡片短信发送回执ID
+	BizSmsID        string `json:"BizSmsId"`        // 回落文本短信发送回执ID
+	BizDigitalID    string `json:"BizDigitalId"`    // 回落数字短信发送回执ID
+	CardTmpState    int    `json:"CardTmpState"`    // 卡片短信模板审核状态
+	MediaMobiles    string `json:"MediaMobiles"`    // 支持卡片短信的手机号
+	NotMediaMobiles string `json:"NotMediaMobiles"` // 不支持卡片短信的手机号
+}
+
+// toJSONString 序列化为JSON字符串, 用于以JSON形式传递的请求参数
+func toJSONString(v interface{}) string {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
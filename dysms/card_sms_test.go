@@ -0,0 +1,43 @@
+package dysms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func Test_SendCardSms(t *testing.T) {
+	r := SendCardSms("123", "测试签名", "CARD_SMS_1", []CardObject{{Mobile: "1390000****", DyncParams: `{"a":"b"}`}}, CardFallbackSMS, "SMS_1", `{"code":"1234"}`)
+	if r.GetCardObjects() != `[{"mobile":"1390000****","dyncParams":"{\"a\":\"b\"}"}]` {
+		t.Error("SetCardObjects failed", r.GetCardObjects())
+	}
+	if r.Request.Get("SmsTemplateCode") != "SMS_1" || r.Request.Get("DigitalTemplateCode") != "" || r.GetFallbackType() != CardFallbackSMS {
+		t.Error("SetFallback failed")
+	}
+
+	b := SendBatchCardSms("", []string{"1390000****"}, []string{"测试签名"}, "CARD_SMS_1", nil, CardFallbackDigitalSMS, "DIGITAL_1", []map[string]string{{"code": "1234"}})
+	if b.Request.Get("DigitalTemplateParamJson") != `[{"code":"1234"}]` || b.GetPhoneNumbers() != `["1390000****"]` {
+		t.Error("SendBatchCardSms params failed")
+	}
+}
+
+func Test_CheckMobilesCardSupport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("Mobiles") != `[{"mobile":"1390000****"},{"mobile":"1380000****"}]` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"RequestId":"r1","Code":"OK","Data":{"queryResult":[{"mobile":"1390000****","support":true},{"mobile":"1380000****","support":false}]}}`))
+	}))
+	defer ts.Close()
+	defer setTestEndPoint(ts.URL)()
+
+	resp, err := CheckMobilesCardSupport("CARD_SMS_1", []string{"1390000****", "1380000****"}).DoActionWithException()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resp.Supported(), []string{"1390000****"}) {
+		t.Error("CheckMobilesCardSupport response failed", resp.String())
+	}
+}
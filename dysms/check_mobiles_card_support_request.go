@@ -0,0 +1,120 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// CheckMobilesCardSupportData 卡片短信支持情况查询结果
+type CheckMobilesCardSupportData struct {
+	QueryResult []CardSupportResult `json:"queryResult"` // 每个手机号的支持情况
+}
+
+// CheckMobilesCardSupportResponse 查询手机号是否支持卡片短信接口服务器响应
+type CheckMobilesCardSupportResponse struct {
+	ErrorMessage
+	Data *CheckMobilesCardSupportData `json:"Data,omitempty"` // 查询结果
+}
+
+// GetQueryResult 获取每个手机号的支持情况
+func (c *CheckMobilesCardSupportResponse) GetQueryResult() []CardSupportResult {
+	if c != nil && c.Data != nil {
+		return c.Data.QueryResult
+	}
+	return nil
+}
+
+// Supported 获取支持卡片短信的手机号
+func (c *CheckMobilesCardSupportResponse) Supported() []string {
+	var mobiles []string
+	for _, r := range c.GetQueryResult() {
+		if r.Support {
+			mobiles = append(mobiles, r.Mobile)
+		}
+	}
+	return mobiles
+}
+
+// String 序列化成JSON字符串
+func (c CheckMobilesCardSupportResponse) String() string {
+	body, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// CheckMobilesCardSupportRequest 查询手机号是否支持卡片短信接口请求
+type CheckMobilesCardSupportRequest struct {
+	Request *Request
+}
+
+// SetTemplateCode 设置卡片短信模板ID 必须
+func (c *CheckMobilesCardSupportRequest) SetTemplateCode(templateCode string) {
+	if c != nil && c.Request != nil {
+		c.Request.Put("TemplateCode", templateCode)
+	}
+}
+
+// GetTemplateCode 获取卡片短信模板ID
+func (c *CheckMobilesCardSupportRequest) GetTemplateCode() string {
+	if c != nil && c.Request != nil {
+		return c.Request.Get("TemplateCode")
+	}
+	return ""
+}
+
+// SetMobiles 设置待查询的手机号列表 必须
+func (c *CheckMobilesCardSupportRequest) SetMobiles(mobiles []string) {
+	if c != nil && c.Request != nil {
+		list := make([]map[string]string, len(mobiles))
+		for i, m := range mobiles {
+			list[i] = map[string]string{"mobile": m}
+		}
+		c.Request.Put("Mobiles", toJSONString(list))
+	}
+}
+
+// GetMobiles 获取待查询的手机号列表, JSON字符串
+func (c *CheckMobilesCardSupportRequest) GetMobiles() string {
+	if c != nil && c.Request != nil {
+		return c.Request.Get("Mobiles")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (c *CheckMobilesCardSupportRequest) DoActionWithException() (resp *CheckMobilesCardSupportResponse, err error) {
+	if c != nil && c.Request != nil {
+		resp := &CheckMobilesCardSupportResponse{}
+		body, httpCode, err := c.Request.Do("CheckMobilesCardSupport")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("CheckMobilesCardSupportRequest is nil")
+}
+
+// CheckMobilesCardSupport 查询手机号是否支持卡片短信接口
+// templateCode 必填 - 卡片短信模板ID
+// mobiles 必填 - 待查询的手机号列表
+func CheckMobilesCardSupport(templateCode string, mobiles []string) *CheckMobilesCardSupportRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "CheckMobilesCardSupport")
+
+	r := &CheckMobilesCardSupportRequest{Request: req}
+	r.SetTemplateCode(templateCode) // 必填 - 卡片短信模板ID
+	r.SetMobiles(mobiles)           // 必填 - 待查询的手机号列表
+	return r
+}
@@ -32,6 +32,26 @@ func init() {
 // Request 请求参数设置
 type Request struct {
 	Param map[string]string
+
+	endPoint string  // 请求的服务地址, 为空时使用默认的服务地址
+	client   *Client // 请求使用的账号, 为nil时使用默认的账号
+}
+
+// SetClient 设置请求使用的账号和服务地址, 为nil时使用 SetACLClient 配置的默认账号
+func (r *Request) SetClient(c *Client) {
+	if r != nil {
+		r.client = c
+		if c != nil {
+			r.Put("AccessKeyId", c.AccessID)
+		}
+	}
+}
+
+// SetEndPoint 设置请求的服务地址, 为空时使用默认的服务地址
+func (r *Request) SetEndPoint(endPoint string) {
+	if r != nil {
+		r.endPoint = endPoint
+	}
 }
 
 // Put 添加请求参数
@@ -73,6 +93,15 @@ func (r *Request) CalcStringToSign(httpMethod string) string {
 
 // Do 发送HTTP请求
 func (r *Request) Do(action string) (body []byte, httpCode int, err error) {
+	return r.do("GET", action)
+}
+
+// DoPost 以POST方式发送HTTP请求, 适用于参数较大的接口, 如上传签名资质文件
+func (r *Request) DoPost(action string) (body []byte, httpCode int, err error) {
+	return r.do("POST", action)
+}
+
+func (r *Request) do(httpMethod, action string) (body []byte, httpCode int, err error) {
 	if r == nil || r.Param == nil {
 		return nil, 0, errors.New("requset is nil")
 	}
@@ -80,10 +109,24 @@ func (r *Request) Do(action string) (body []byte, httpCode int, err error) {
 	if action != "" {
 		r.Put("Action", action)
 	}
-	signature := signatureMethod(acsClient.AccessKey, r.CalcStringToSign("GET"))
+	// 同一个请求可能被多次发送(如分页查询), 每次发送需使用新的防重放序列
+	r.Put("SignatureNonce", uuid.New())
+	r.Put("Timestamp", time.Now().UTC().Format(time.RFC3339))
+	client := &acsClient
+	if r.client != nil {
+		client = r.client
+	}
+	signature := signatureMethod(client.AccessKey, r.CalcStringToSign(httpMethod))
+	endPoint := client.EndPoint
+	if r.endPoint != "" {
+		endPoint = r.endPoint
+	}
 
 	// HTTP requset
-	httpReq := urllib.Get(acsClient.EndPoint)
+	httpReq := urllib.Get(endPoint)
+	if httpMethod == "POST" {
+		httpReq = urllib.Post(endPoint)
+	}
 	if HTTPDebugEnable {
 		httpReq.Debug(true)
 	}
@@ -245,6 +288,18 @@ func SetACLClient(accessid, accesskey string) *Client {
 	return &acsClient
 }
 
+// NewClient 创建一个独立的账号配置, 不影响 SetACLClient 配置的默认账号
+// 通过 Request.SetClient 让请求使用该账号, 可同时使用多个账号
+func NewClient(accessid, accesskey string) *Client {
+	c := &Client{}
+	c.SetVersion("2017-05-25")
+	c.SetRegion("cn-hangzhou")
+	c.SetEndPoint("http://dysmsapi.aliyuncs.com/")
+	c.SetAccessID(accessid)
+	c.SetAccessKey(accesskey)
+	return c
+}
+
 // New 兼容 sms SDK
 func New(accessid, accesskey string) *Client {
 	return SetACLClient(accessid, accesskey)
@@ -1,9 +1,20 @@
 package dysms
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
+// setTestEndPoint 将默认账号和服务地址指向本地测试服务器, 返回恢复函数
+func setTestEndPoint(endPoint string) func() {
+	old := acsClient
+	SetACLClient("testId", "testSecret").SetEndPoint(endPoint)
+	return func() {
+		acsClient = old
+	}
+}
+
 func Test_stringToSign(t *testing.T) {
 	c := new(Client)
 	c.EndPoint = "http://dysmsapi.aliyuncs.com/"
@@ -33,3 +44,21 @@ func Test_stringToSign(t *testing.T) {
 		t.Error("calcStringToSign failed")
 	}
 }
+
+func Test_RequestSetClient(t *testing.T) {
+	var accessKeyID string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accessKeyID = r.URL.Query().Get("AccessKeyId")
+		w.Write([]byte(`{"Code":"OK"}`))
+	}))
+	defer ts.Close()
+	defer setTestEndPoint("http://127.0.0.1:0/")()
+
+	c := NewClient("otherId", "otherSecret")
+	c.SetEndPoint(ts.URL + "/")
+	r := SendSms("1", "13800000000", "sign", "SMS_1", "")
+	r.Request.SetClient(c)
+	if _, err := r.DoActionWithException(); err != nil || accessKeyID != "otherId" || acsClient.AccessID != "testId" {
+		t.Error("SetClient failed", accessKeyID, err)
+	}
+}
@@ -0,0 +1,74 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// DeleteShortURLResponse 删除短链接口服务器响应
+type DeleteShortURLResponse struct {
+	ErrorMessage
+}
+
+// String 序列化成JSON字符串
+func (d DeleteShortURLResponse) String() string {
+	body, err := json.Marshal(d)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// DeleteShortURLRequest 删除短链接口请求
+type DeleteShortURLRequest struct {
+	Request *Request
+}
+
+// SetSourceURL 设置原始链接地址 必须
+func (d *DeleteShortURLRequest) SetSourceURL(sourceURL string) {
+	if d != nil && d.Request != nil {
+		d.Request.Put("SourceUrl", sourceURL)
+	}
+}
+
+// GetSourceURL 获取原始链接地址
+func (d *DeleteShortURLRequest) GetSourceURL() string {
+	if d != nil && d.Request != nil {
+		return d.Request.Get("SourceUrl")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (d *DeleteShortURLRequest) DoActionWithException() (resp *DeleteShortURLResponse, err error) {
+	if d != nil && d.Request != nil {
+		resp := &DeleteShortURLResponse{}
+		body, httpCode, err := d.Request.Do("DeleteShortUrl")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("DeleteShortURLRequest is nil")
+}
+
+// DeleteShortURL 删除短链接口, 删除后短链将无法使用
+// sourceURL 必填 - 原始链接地址
+func DeleteShortURL(sourceURL string) *DeleteShortURLRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "DeleteShortUrl")
+
+	r := &DeleteShortURLRequest{Request: req}
+	r.SetSourceURL(sourceURL) // 必填 - 原始链接地址
+	return r
+}
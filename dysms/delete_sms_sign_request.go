@@ -0,0 +1,83 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// DeleteSmsSignResponse 删除短信签名接口服务器响应
+type DeleteSmsSignResponse struct {
+	ErrorMessage
+	SignName *string `json:"SignName,omitempty"` // 签名名称
+}
+
+// GetSignName 获取签名名称
+func (d *DeleteSmsSignResponse) GetSignName() string {
+	if d != nil && d.SignName != nil {
+		return *d.SignName
+	}
+	return ""
+}
+
+// String 序列化成JSON字符串
+func (d DeleteSmsSignResponse) String() string {
+	body, err := json.Marshal(d)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// DeleteSmsSignRequest 删除短信签名接口请求
+type DeleteSmsSignRequest struct {
+	Request *Request
+}
+
+// SetSignName 设置签名名称 必须
+func (d *DeleteSmsSignRequest) SetSignName(signName string) {
+	if d != nil && d.Request != nil {
+		d.Request.Put("SignName", signName)
+	}
+}
+
+// GetSignName 获取签名名称
+func (d *DeleteSmsSignRequest) GetSignName() string {
+	if d != nil && d.Request != nil {
+		return d.Request.Get("SignName")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (d *DeleteSmsSignRequest) DoActionWithException() (resp *DeleteSmsSignResponse, err error) {
+	if d != nil && d.Request != nil {
+		resp := &DeleteSmsSignResponse{}
+		body, httpCode, err := d.Request.Do("DeleteSmsSign")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("DeleteSmsSignRequest is nil")
+}
+
+// DeleteSmsSign 删除短信签名接口, 审核中的签名不支持删除
+// signName 必填 - 签名名称
+func DeleteSmsSign(signName string) *DeleteSmsSignRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "DeleteSmsSign")
+
+	r := &DeleteSmsSignRequest{Request: req}
+	r.SetSignName(signName) // 必填 - 签名名称
+	return r
+}
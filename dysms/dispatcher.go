@@ -0,0 +1,225 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// 异步发送队列错误
+var (
+	ErrQueueFull        = errors.New("dispatcher queue is full")
+	ErrDispatcherClosed = errors.New("dispatcher is closed")
+)
+
+// 异步发送队列的默认配置
+const (
+	DefaultDispatcherQueueSize = 1000
+	DefaultDispatcherWorkers   = 4
+)
+
+// SendFuture 异步发送的结果
+type SendFuture struct {
+	Request *SendSmsRequest
+
+	done chan struct{}
+	resp *SendSmsResponse
+	err  error
+}
+
+// Done 发送完成后关闭的通道
+func (f *SendFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait 等待发送完成, ctx 结束时返回 ctx.Err(), 但不会取消发送
+func (f *SendFuture) Wait(ctx context.Context) (*SendSmsResponse, error) {
+	select {
+	case <-f.done:
+		return f.resp, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// DispatcherStats 异步发送队列的统计信息
+type DispatcherStats struct {
+	QueueDepth    int   // 队列中等待发送的短信数量
+	QueueCapacity int   // 队列容量
+	InFlight      int64 // 正在发送的短信数量
+	Enqueued      int64 // 累计入队数量
+	Rejected      int64 // 累计因队列已满或已关闭被拒绝的数量
+	Succeeded     int64 // 累计发送成功数量
+	Failed        int64 // 累计发送失败数量
+}
+
+// Dispatcher 异步发送队列, 由固定数量的工作协程从有界队列中取出短信发送
+// 字段需在第一次调用 Enqueue 之前设置
+type Dispatcher struct {
+	QueueSize  int                                             // 队列容量, 为0时使用 DefaultDispatcherQueueSize
+	Workers    int                                             // 工作协程数量, 为0时使用 DefaultDispatcherWorkers
+	Send       func(*SendSmsRequest) (*SendSmsResponse, error) // 发送函数, 可包装重试和限流, 为nil时直接调用 DoActionWithException
+	OnComplete func(*SendSmsRequest, *SendSmsResponse, error)  // 可选, 每条短信发送完成后在工作协程中回调
+
+	once     sync.Once
+	mu       sync.RWMutex
+	closed   bool
+	quit     chan struct{}
+	queue    chan *SendFuture
+	enqueues sync.WaitGroup
+	workers  sync.WaitGroup
+	stopped  chan struct{}
+
+	inFlight  int64
+	enqueued  int64
+	rejected  int64
+	succeeded int64
+	failed    int64
+}
+
+// NewDispatcher 创建一个异步发送队列
+func NewDispatcher(queueSize, workers int) *Dispatcher {
+	return &Dispatcher{QueueSize: queueSize, Workers: workers}
+}
+
+// start 启动工作协程
+func (d *Dispatcher) start() {
+	d.once.Do(func() {
+		if d.QueueSize <= 0 {
+			d.QueueSize = DefaultDispatcherQueueSize
+		}
+		if d.Workers <= 0 {
+			d.Workers = DefaultDispatcherWorkers
+		}
+		d.quit = make(chan struct{})
+		d.stopped = make(chan struct{})
+		d.queue = make(chan *SendFuture, d.QueueSize)
+		for i := 0; i < d.Workers; i++ {
+			d.workers.Add(1)
+			go d.work()
+		}
+		go func() {
+			d.workers.Wait()
+			close(d.stopped)
+		}()
+	})
+}
+
+// work 工作协程, 队列关闭后处理完剩余的短信再退出
+func (d *Dispatcher) work() {
+	defer d.workers.Done()
+	for f := range d.queue {
+		atomic.AddInt64(&d.inFlight, 1)
+		f.resp, f.err = d.send(f.Request)
+		atomic.AddInt64(&d.inFlight, -1)
+		if f.err != nil {
+			atomic.AddInt64(&d.failed, 1)
+		} else {
+			atomic.AddInt64(&d.succeeded, 1)
+		}
+		if d.OnComplete != nil {
+			d.OnComplete(f.Request, f.resp, f.err)
+		}
+		close(f.done)
+	}
+}
+
+// send 发送一条短信, Send 发生 panic 时作为该短信的错误返回, 工作协程继续运行
+func (d *Dispatcher) send(r *SendSmsRequest) (resp *SendSmsResponse, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			resp, err = nil, fmt.Errorf("dispatcher: send panic: %v", p)
+		}
+	}()
+	if d.Send == nil {
+		return sendSms(r)
+	}
+	return d.Send(r)
+}
+
+// enqueue 将短信放入队列, ctx 为nil时队列已满立即返回 ErrQueueFull
+func (d *Dispatcher) enqueue(ctx context.Context, r *SendSmsRequest) (*SendFuture, error) {
+	if r == nil || r.Request == nil {
+		return nil, errors.New("SendSmsRequest is nil")
+	}
+	d.start()
+	d.mu.RLock()
+	if d.closed {
+		d.mu.RUnlock()
+		atomic.AddInt64(&d.rejected, 1)
+		return nil, ErrDispatcherClosed
+	}
+	d.enqueues.Add(1)
+	d.mu.RUnlock()
+	defer d.enqueues.Done()
+
+	f := &SendFuture{Request: r, done: make(chan struct{})}
+	select {
+	case d.queue <- f:
+		atomic.AddInt64(&d.enqueued, 1)
+		return f, nil
+	default:
+	}
+	if ctx == nil {
+		atomic.AddInt64(&d.rejected, 1)
+		return nil, ErrQueueFull
+	}
+	select {
+	case d.queue <- f:
+		atomic.AddInt64(&d.enqueued, 1)
+		return f, nil
+	case <-ctx.Done():
+		atomic.AddInt64(&d.rejected, 1)
+		return nil, ctx.Err()
+	case <-d.quit:
+		atomic.AddInt64(&d.rejected, 1)
+		return nil, ErrDispatcherClosed
+	}
+}
+
+// Enqueue 将短信放入队列, 队列已满时阻塞等待, ctx 结束时返回 ctx.Err()
+func (d *Dispatcher) Enqueue(ctx context.Context, r *SendSmsRequest) (*SendFuture, error) {
+	return d.enqueue(ctx, r)
+}
+
+// TryEnqueue 将短信放入队列, 队列已满时立即返回 ErrQueueFull
+func (d *Dispatcher) TryEnqueue(r *SendSmsRequest) (*SendFuture, error) {
+	return d.enqueue(nil, r)
+}
+
+// Stats 获取统计信息
+func (d *Dispatcher) Stats() DispatcherStats {
+	d.start()
+	return DispatcherStats{
+		QueueDepth:    len(d.queue),
+		QueueCapacity: cap(d.queue),
+		InFlight:      atomic.LoadInt64(&d.inFlight),
+		Enqueued:      atomic.LoadInt64(&d.enqueued),
+		Rejected:      atomic.LoadInt64(&d.rejected),
+		Succeeded:     atomic.LoadInt64(&d.succeeded),
+		Failed:        atomic.LoadInt64(&d.failed),
+	}
+}
+
+// Shutdown 停止接收新的短信, 并等待队列中的短信发送完毕
+// ctx 结束时立即返回 ctx.Err(), 剩余的短信仍会在后台继续发送
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.start()
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.quit)
+		d.enqueues.Wait()
+		close(d.queue)
+	}
+	d.mu.Unlock()
+	select {
+	case <-d.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,79 @@
+package dysms
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_Dispatcher(t *testing.T) {
+	release := make(chan struct{})
+	var completed int32
+	d := NewDispatcher(1, 1)
+	d.Send = func(r *SendSmsRequest) (*SendSmsResponse, error) {
+		<-release
+		switch r.GetPhoneNumbers() {
+		case "13800000002":
+			return nil, errors.New("send failed")
+		case "13800000005":
+			panic("send panic")
+		}
+		bizID := "biz-" + r.GetPhoneNumbers()
+		return &SendSmsResponse{BizID: &bizID}, nil
+	}
+	d.OnComplete = func(*SendSmsRequest, *SendSmsResponse, error) {
+		atomic.AddInt32(&completed, 1)
+	}
+
+	f1, err := d.TryEnqueue(SendSms("1", "13800000001", "sign", "SMS_1", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 等待工作协程取走第一条, 队列中再放入一条后队列已满
+	for d.Stats().InFlight != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	f2, err := d.TryEnqueue(SendSms("1", "13800000002", "sign", "SMS_1", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.TryEnqueue(SendSms("1", "13800000003", "sign", "SMS_1", "")); err != ErrQueueFull {
+		t.Error("TryEnqueue should reject when queue is full", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := d.Enqueue(ctx, SendSms("1", "13800000003", "sign", "SMS_1", "")); err != context.DeadlineExceeded {
+		t.Error("Enqueue should give up when ctx is done", err)
+	}
+	if s := d.Stats(); s.QueueDepth != 1 || s.QueueCapacity != 1 || s.Rejected != 2 {
+		t.Error("Stats failed", s)
+	}
+
+	close(release)
+	// Send 发生 panic 时作为该短信的错误返回, 工作协程继续处理后续短信
+	f3, err := d.Enqueue(context.Background(), SendSms("1", "13800000005", "sign", "SMS_1", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f3.Wait(context.Background()); err == nil || !strings.Contains(err.Error(), "send panic") {
+		t.Error("future should report panic", err)
+	}
+	if err := d.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := f1.Wait(context.Background()); err != nil || resp.GetBizID() != "biz-13800000001" {
+		t.Error("future failed", resp, err)
+	}
+	if _, err := f2.Wait(context.Background()); err == nil {
+		t.Error("future should fail")
+	}
+	if s := d.Stats(); s.Succeeded != 1 || s.Failed != 2 || completed != 3 {
+		t.Error("Stats failed", s)
+	}
+	if _, err := d.TryEnqueue(SendSms("1", "13800000004", "sign", "SMS_1", "")); err != ErrDispatcherClosed {
+		t.Error("TryEnqueue should reject after shutdown", err)
+	}
+}
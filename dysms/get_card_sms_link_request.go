@@ -0,0 +1,215 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// CardSmsLinkData 卡片短信短链信息
+type CardSmsLinkData struct {
+	CardTmpState     int    `json:"CardTmpState"`     // 卡片短信模板审核状态
+	CardPhoneNumbers string `json:"CardPhoneNumbers"` // 支持卡片短信的手机号
+	CardSmsLinks     string `json:"CardSmsLinks"`     // 卡片短信短链
+	NotMediaMobiles  string `json:"NotMediaMobiles"`  // 不支持卡片短信的手机号
+}
+
+// GetCardSmsLinkResponse 获取卡片短信短链接口服务器响应
+type GetCardSmsLinkResponse struct {
+	ErrorMessage
+	Data *CardSmsLinkData `json:"Data,omitempty"` // 短链信息
+}
+
+// GetData 获取短链信息
+func (g *GetCardSmsLinkResponse) GetData() *CardSmsLinkData {
+	if g != nil && g.Data != nil {
+		return g.Data
+	}
+	return nil
+}
+
+// String 序列化成JSON字符串
+func (g GetCardSmsLinkResponse) String() string {
+	body, err := json.Marshal(g)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// GetCardSmsLinkRequest 获取卡片短信短链接口请求
+type GetCardSmsLinkRequest struct {
+	Request *Request
+}
+
+// SetOutID 设置外部流水扩展字段
+func (g *GetCardSmsLinkRequest) SetOutID(outID string) {
+	if g != nil && g.Request != nil {
+		g.Request.Put("OutId", outID)
+	}
+}
+
+// GetOutID 获取外部流水扩展字段
+func (g *GetCardSmsLinkRequest) GetOutID() string {
+	if g != nil && g.Request != nil {
+		return g.Request.Get("OutId")
+	}
+	return ""
+}
+
+// SetCardTemplateCode 设置卡片短信模板ID 必须
+func (g *GetCardSmsLinkRequest) SetCardTemplateCode(cardTemplateCode string) {
+	if g != nil && g.Request != nil {
+		g.Request.Put("CardTemplateCode", cardTemplateCode)
+	}
+}
+
+// GetCardTemplateCode 获取卡片短信模板ID
+func (g *GetCardSmsLinkRequest) GetCardTemplateCode() string {
+	if g != nil && g.Request != nil {
+		return g.Request.Get("CardTemplateCode")
+	}
+	return ""
+}
+
+// SetPhoneNumbers 设置接收短信的手机号列表
+func (g *GetCardSmsLinkRequest) SetPhoneNumbers(phoneNumbers []string) {
+	if g != nil && g.Request != nil {
+		g.Request.Put("PhoneNumberJson", toJSONString(phoneNumbers))
+	}
+}
+
+// GetPhoneNumbers 获取接收短信的手机号列表, JSON字符串
+func (g *GetCardSmsLinkRequest) GetPhoneNumbers() string {
+	if g != nil && g.Request != nil {
+		return g.Request.Get("PhoneNumberJson")
+	}
+	return ""
+}
+
+// SetSignNames 设置短信签名列表
+func (g *GetCardSmsLinkRequest) SetSignNames(signNames []string) {
+	if g != nil && g.Request != nil {
+		g.Request.Put("SignNameJson", toJSONString(signNames))
+	}
+}
+
+// GetSignNames 获取短信签名列表, JSON字符串
+func (g *GetCardSmsLinkRequest) GetSignNames() string {
+	if g != nil && g.Request != nil {
+		return g.Request.Get("SignNameJson")
+	}
+	return ""
+}
+
+// SetCardTemplateParams 设置卡片短信动态参数列表
+func (g *GetCardSmsLinkRequest) SetCardTemplateParams(cardTemplateParams []map[string]string) {
+	if g != nil && g.Request != nil {
+		g.Request.Put("CardTemplateParamJson", toJSONString(cardTemplateParams))
+	}
+}
+
+// GetCardTemplateParams 获取卡片短信动态参数列表, JSON字符串
+func (g *GetCardSmsLinkRequest) GetCardTemplateParams() string {
+	if g != nil && g.Request != nil {
+		return g.Request.Get("CardTemplateParamJson")
+	}
+	return ""
+}
+
+// SetCardCodeType 设置短码类型 1：群发码, 2：个性化码
+func (g *GetCardSmsLinkRequest) SetCardCodeType(cardCodeType int) {
+	if g != nil && g.Request != nil {
+		g.Request.Put("CardCodeType", strconv.Itoa(cardCodeType))
+	}
+}
+
+// GetCardCodeType 获取短码类型
+func (g *GetCardSmsLinkRequest) GetCardCodeType() int {
+	if g != nil && g.Request != nil {
+		n, _ := strconv.Atoi(g.Request.Get("CardCodeType"))
+		return n
+	}
+	return 0
+}
+
+// SetCardLinkType 设置短链类型 1：标准生成短码, 2：自定义生成短码
+func (g *GetCardSmsLinkRequest) SetCardLinkType(cardLinkType int) {
+	if g != nil && g.Request != nil {
+		g.Request.Put("CardLinkType", strconv.Itoa(cardLinkType))
+	}
+}
+
+// GetCardLinkType 获取短链类型
+func (g *GetCardSmsLinkRequest) GetCardLinkType() int {
+	if g != nil && g.Request != nil {
+		n, _ := strconv.Atoi(g.Request.Get("CardLinkType"))
+		return n
+	}
+	return 0
+}
+
+// SetDomain 设置短链使用的域名, 自定义生成短码时有效
+func (g *GetCardSmsLinkRequest) SetDomain(domain string) {
+	if g != nil && g.Request != nil {
+		g.Request.Put("Domain", domain)
+	}
+}
+
+// GetDomain 获取短链使用的域名
+func (g *GetCardSmsLinkRequest) GetDomain() string {
+	if g != nil && g.Request != nil {
+		return g.Request.Get("Domain")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (g *GetCardSmsLinkRequest) DoActionWithException() (resp *GetCardSmsLinkResponse, err error) {
+	if g != nil && g.Request != nil {
+		resp := &GetCardSmsLinkResponse{}
+		body, httpCode, err := g.Request.DoPost("GetCardSmsLink")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("GetCardSmsLinkRequest is nil")
+}
+
+// GetCardSmsLink 获取卡片短信短链接口
+// businessID 设置业务请求流水号
+// cardTemplateCode 必填 - 卡片短信模板ID
+// phoneNumbers 接收短信的手机号列表
+// signNames 短信签名列表
+// cardTemplateParams 卡片短信动态参数列表
+func GetCardSmsLink(businessID, cardTemplateCode string, phoneNumbers, signNames []string, cardTemplateParams []map[string]string) *GetCardSmsLinkRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "GetCardSmsLink")
+
+	r := &GetCardSmsLinkRequest{Request: req}
+	if businessID != "" {
+		r.SetOutID(businessID) // 业务请求流水号
+	}
+	r.SetCardTemplateCode(cardTemplateCode) // 必填 - 卡片短信模板ID
+	if len(phoneNumbers) > 0 {
+		r.SetPhoneNumbers(phoneNumbers) // 接收短信的手机号列表
+	}
+	if len(signNames) > 0 {
+		r.SetSignNames(signNames) // 短信签名列表
+	}
+	if len(cardTemplateParams) > 0 {
+		r.SetCardTemplateParams(cardTemplateParams) // 卡片短信动态参数列表
+	}
+	return r
+}
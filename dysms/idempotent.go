@@ -0,0 +1,216 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrOutIDRequired 幂等发送需要设置外部流水扩展字段
+var ErrOutIDRequired = errors.New("idempotent send should have an OutId")
+
+// 幂等发送的默认参数
+const (
+	DefaultIdempotencyWindow = 24 * time.Hour  // 默认的去重窗口
+	DefaultRecheckDelay      = 3 * time.Second // 请求结果不确定时, 查询发送记录前的等待时间
+	DefaultMaxResend         = 2               // 请求结果不确定时的最大重发次数
+)
+
+// IdempotencyStore 以 OutId 为键保存发送成功的响应
+type IdempotencyStore interface {
+	Load(outID string) (*SendSmsResponse, bool)
+	Store(outID string, resp *SendSmsResponse, window time.Duration)
+}
+
+// memoryIdempotencyEntry 内存中保存的响应
+type memoryIdempotencyEntry struct {
+	resp     *SendSmsResponse
+	expireAt time.Time
+}
+
+// MemoryIdempotencyStore 基于内存的 IdempotencyStore
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryIdempotencyEntry
+}
+
+// NewMemoryIdempotencyStore 创建一个基于内存的 IdempotencyStore
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{entries: make(map[string]memoryIdempotencyEntry)}
+}
+
+// Load 获取去重窗口内保存的响应
+func (m *MemoryIdempotencyStore) Load(outID string) (*SendSmsResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[outID]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expireAt) {
+		delete(m.entries, outID)
+		return nil, false
+	}
+	return e.resp, true
+}
+
+// Store 保存响应, 同时清理已过期的响应
+func (m *MemoryIdempotencyStore) Store(outID string, resp *SendSmsResponse, window time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = make(map[string]memoryIdempotencyEntry)
+	}
+	now := time.Now()
+	for id, e := range m.entries {
+		if now.After(e.expireAt) {
+			delete(m.entries, id)
+		}
+	}
+	m.entries[outID] = memoryIdempotencyEntry{resp: resp, expireAt: now.Add(window)}
+}
+
+// idempotentCall 正在进行中的发送
+type idempotentCall struct {
+	done chan struct{}
+	resp *SendSmsResponse
+	err  error
+}
+
+// IdempotentSender 以 OutId 为幂等键发送短信
+// 去重窗口内相同 OutId 的请求直接返回第一次发送成功的响应, 并发的相同请求只发送一次
+// 请求超时等无法确定是否已被受理时, 先通过 QuerySendDetails 查询是否存在相同 OutId 的发送记录, 不存在时才重发
+type IdempotentSender struct {
+	Store        IdempotencyStore                                // 响应存储, 为nil时使用内存存储
+	Window       time.Duration                                   // 去重窗口, 为0时使用 DefaultIdempotencyWindow
+	RecheckDelay time.Duration                                   // 查询发送记录前的等待时间, 为0时使用 DefaultRecheckDelay
+	MaxResend    int                                             // 最大重发次数, 为0时使用 DefaultMaxResend
+	Send         func(*SendSmsRequest) (*SendSmsResponse, error) // 发送函数, 为nil时直接调用 DoActionWithException
+
+	once     sync.Once
+	mu       sync.Mutex
+	inflight map[string]*idempotentCall
+}
+
+// NewIdempotentSender 创建一个幂等发送者, store 为nil时使用内存存储
+func NewIdempotentSender(store IdempotencyStore, window time.Duration) *IdempotentSender {
+	return &IdempotentSender{Store: store, Window: window}
+}
+
+func (s *IdempotentSender) init() {
+	s.once.Do(func() {
+		if s.Store == nil {
+			s.Store = NewMemoryIdempotencyStore()
+		}
+		if s.Window <= 0 {
+			s.Window = DefaultIdempotencyWindow
+		}
+		if s.RecheckDelay <= 0 {
+			s.RecheckDelay = DefaultRecheckDelay
+		}
+		if s.MaxResend <= 0 {
+			s.MaxResend = DefaultMaxResend
+		}
+		if s.Send == nil {
+			s.Send = sendSms
+		}
+		s.inflight = make(map[string]*idempotentCall)
+	})
+}
+
+// SendSms 幂等地发送短信, 请求必须设置 OutId
+func (s *IdempotentSender) SendSms(ctx context.Context, r *SendSmsRequest) (*SendSmsResponse, error) {
+	if r == nil || r.Request == nil {
+		return nil, errors.New("SendSmsRequest is nil")
+	}
+	outID := r.Request.Get("OutId")
+	if outID == "" {
+		return nil, ErrOutIDRequired
+	}
+	s.init()
+	if resp, ok := s.Store.Load(outID); ok {
+		return resp, nil
+	}
+
+	s.mu.Lock()
+	if c, ok := s.inflight[outID]; ok {
+		s.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.resp, c.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	c := &idempotentCall{done: make(chan struct{})}
+	s.inflight[outID] = c
+	s.mu.Unlock()
+
+	c.resp, c.err = s.send(ctx, outID, r)
+	if c.err == nil {
+		s.Store.Store(outID, c.resp, s.Window)
+	}
+	s.mu.Lock()
+	delete(s.inflight, outID)
+	s.mu.Unlock()
+	close(c.done)
+	return c.resp, c.err
+}
+
+// send 发送短信, 结果不确定时查询发送记录后再决定是否重发
+func (s *IdempotentSender) send(ctx context.Context, outID string, r *SendSmsRequest) (*SendSmsResponse, error) {
+	resp, err := s.Send(r)
+	for i := 0; i < s.MaxResend && ambiguous(resp, err); i++ {
+		timer := time.NewTimer(s.RecheckDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, ctx.Err()
+		case <-timer.C:
+		}
+		found, qerr := sentWithOutID(ctx, r.GetPhoneNumbers(), outID)
+		if qerr != nil {
+			return resp, err
+		}
+		if found != nil {
+			return found, nil
+		}
+		resp, err = s.Send(r)
+	}
+	return resp, err
+}
+
+// ambiguous 是否无法确定请求是否已被受理, 即没有收到服务器的响应
+func ambiguous(resp *SendSmsResponse, err error) bool {
+	return err != nil && (resp == nil || resp.GetHTTPCode() == 0)
+}
+
+// sentWithOutID 查询第一个号码当天和前一天是否存在相同 OutId 的发送记录, 存在时返回一个成功的响应
+// 请求跨越零点时发送记录可能在前一天; 返回的 BizID 为发送记录中的 BizId
+func sentWithOutID(ctx context.Context, phoneNumbers, outID string) (*SendSmsResponse, error) {
+	phoneNumber := strings.Split(phoneNumbers, ",")[0]
+	now := time.Now()
+	for _, day := range []time.Time{now, now.Add(-24 * time.Hour)} {
+		date, err := formatSendDate(day)
+		if err != nil {
+			return nil, err
+		}
+		it := NewSendDetailsIterator(ctx, QuerySendDetails("", phoneNumber, "", "1", date))
+		for it.Next() {
+			if d := it.Detail(); d.OutID == outID {
+				code, bizID := "OK", d.BizID
+				resp := &SendSmsResponse{BizID: &bizID}
+				resp.Code = &code
+				resp.SetHTTPCode(200)
+				return resp, nil
+			}
+		}
+		if err := it.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,56 @@
+package dysms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_IdempotentSender(t *testing.T) {
+	yesterday := time.Now().In(CSTZone).AddDate(0, 0, -1).Format("20060102")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 发送记录在前一天, 模拟请求跨越零点
+		outID := "accepted"
+		if r.URL.Query().Get("PhoneNumber") == "13800000002" || r.URL.Query().Get("SendDate") != yesterday {
+			outID = "other"
+		}
+		fmt.Fprintf(w, `{"Code":"OK","TotalCount":1,"SmsSendDetailDTOs":{"SmsSendDetailDTO":[{"PhoneNum":"13800000001","SendStatus":1,"OutId":"%s","BizId":"biz-%s"}]}}`, outID, outID)
+	}))
+	defer ts.Close()
+	defer setTestEndPoint(ts.URL + "/")()
+
+	sends := make(map[string]int)
+	s := NewIdempotentSender(nil, time.Minute)
+	s.RecheckDelay = time.Millisecond
+	s.Send = func(r *SendSmsRequest) (*SendSmsResponse, error) {
+		sends[r.GetPhoneNumbers()]++
+		if sends[r.GetPhoneNumbers()] == 1 {
+			return &SendSmsResponse{}, errors.New("i/o timeout")
+		}
+		bizID := "biz"
+		return &SendSmsResponse{BizID: &bizID}, nil
+	}
+
+	// 超时, 但查询到相同 OutId 的发送记录, 不再重发
+	resp, err := s.SendSms(context.Background(), SendSms("accepted", "13800000001", "sign", "SMS_1", ""))
+	if err != nil || resp.GetCode() != "OK" || resp.GetBizID() != "biz-accepted" || sends["13800000001"] != 1 {
+		t.Error("IdempotentSender recheck failed", resp, err, sends)
+	}
+	// 去重窗口内直接返回第一次的响应
+	again, err := s.SendSms(context.Background(), SendSms("accepted", "13800000001", "sign", "SMS_1", ""))
+	if err != nil || again != resp || sends["13800000001"] != 1 {
+		t.Error("IdempotentSender dedupe failed", again, err, sends)
+	}
+	// 超时, 且没有发送记录, 重发
+	resp, err = s.SendSms(context.Background(), SendSms("resend", "13800000002", "sign", "SMS_1", ""))
+	if err != nil || resp.GetBizID() != "biz" || sends["13800000002"] != 2 {
+		t.Error("IdempotentSender resend failed", resp, err, sends)
+	}
+	if _, err := s.SendSms(context.Background(), SendSms("", "13800000002", "sign", "SMS_1", "")); err != ErrOutIDRequired {
+		t.Error("IdempotentSender should require OutId", err)
+	}
+}
@@ -0,0 +1,172 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// GlobeEndPoint 国际/港澳台短信服务地址(新加坡)
+var GlobeEndPoint = "http://dysmsapi.ap-southeast-1.aliyuncs.com/"
+
+// globeRegion 国际/港澳台短信服务地域
+const globeRegion = "ap-southeast-1"
+
+// ErrTemplateNotInternational 短信模板不是国际/港澳台消息模板
+var ErrTemplateNotInternational = errors.New("template is not an international template")
+
+// NumberDetail 号码详情
+type NumberDetail struct {
+	Carrier string `json:"Carrier"` // 号码所属的运营商
+	Country string `json:"Country"` // 号码所属的国家
+	Region  string `json:"Region"`  // 号码所属的地区
+}
+
+// GlobeErrorMessage 国际/港澳台短信服务器返回的错误信息
+type GlobeErrorMessage struct {
+	ErrorMessage
+	ResponseCode        *string `json:"ResponseCode,omitempty"`        // 状态码, 返回OK代表请求成功
+	ResponseDescription *string `json:"ResponseDescription,omitempty"` // 状态码的描述
+}
+
+// GetResponseCode 获取状态码
+func (g *GlobeErrorMessage) GetResponseCode() string {
+	if g != nil && g.ResponseCode != nil {
+		return *g.ResponseCode
+	}
+	return ""
+}
+
+// GetResponseDescription 获取状态码的描述
+func (g *GlobeErrorMessage) GetResponseDescription() string {
+	if g != nil && g.ResponseDescription != nil {
+		return *g.ResponseDescription
+	}
+	return ""
+}
+
+// globeError 根据HTTP错误码和状态码生成错误
+func (g *GlobeErrorMessage) globeError(httpCode int) error {
+	if httpCode != 200 {
+		return errors.New(g.GetCode())
+	}
+	if code := g.GetResponseCode(); code != "" && code != "OK" {
+		return errors.New(code)
+	}
+	return nil
+}
+
+// 创建一个新的国际/港澳台短信请求参数
+func newGlobeRequset() *Request {
+	req := newRequset()
+	req.Put("Version", "2018-05-01")
+	req.Put("RegionId", globeRegion)
+	req.endPoint = GlobeEndPoint
+	return req
+}
+
+// ParseE164PhoneNumber 解析E.164格式的号码, 如+6591234567, 返回国际区号+号码, 如6591234567
+func ParseE164PhoneNumber(phoneNumber string) (string, error) {
+	n := strings.TrimSpace(phoneNumber)
+	if !strings.HasPrefix(n, "+") {
+		return "", errors.New("phone number should start with +: " + phoneNumber)
+	}
+	n = n[1:]
+	if len(n) < 8 || len(n) > 15 || n[0] == '0' {
+		return "", errors.New("invalid E.164 phone number: " + phoneNumber)
+	}
+	for _, c := range n {
+		if c < '0' || c > '9' {
+			return "", errors.New("invalid E.164 phone number: " + phoneNumber)
+		}
+	}
+	return n, nil
+}
+
+// SetInternationalPhoneNumbers 设置国际/港澳台短信接收号码并开启国际短信模式
+// 号码为E.164格式, 如+6591234567, 将被转换为00+国际区号+号码的格式, 如006591234567
+// 开启国际短信模式后, 发送前会校验短信模板是否为国际/港澳台消息模板
+func (s *SendSmsRequest) SetInternationalPhoneNumbers(phoneNumbers ...string) error {
+	if s == nil || s.Request == nil {
+		return errors.New("SendSmsRequest is nil")
+	}
+	numbers := make([]string, len(phoneNumbers))
+	for i, phoneNumber := range phoneNumbers {
+		n, err := ParseE164PhoneNumber(phoneNumber)
+		if err != nil {
+			return err
+		}
+		numbers[i] = "00" + n
+	}
+	s.SetPhoneNumbers(strings.Join(numbers, ","))
+	s.international = true
+	return nil
+}
+
+// IsInternational 是否为国际短信模式
+func (s *SendSmsRequest) IsInternational() bool {
+	return s != nil && s.international
+}
+
+// internationalTemplates 已校验过的模板类型缓存, 以accessid和模板CODE为键
+var internationalTemplates = struct {
+	sync.Mutex
+	types map[string]TemplateType
+}{types: make(map[string]TemplateType)}
+
+// checkInternationalTemplate 使用请求的账号和服务地址校验短信模板是否为国际/港澳台消息模板
+func checkInternationalTemplate(r *Request, templateCode string) error {
+	client := &acsClient
+	if r.client != nil {
+		client = r.client
+	}
+	key := client.AccessID + ":" + templateCode
+	internationalTemplates.Lock()
+	t, ok := internationalTemplates.types[key]
+	internationalTemplates.Unlock()
+	if !ok {
+		q := QuerySmsTemplate(templateCode)
+		q.Request.SetClient(r.client)
+		q.Request.SetEndPoint(r.endPoint)
+		resp, err := q.DoActionWithException()
+		if err != nil {
+			return err
+		}
+		if resp.TemplateType == nil {
+			return &resp.ErrorMessage
+		}
+		t = resp.GetTemplateType()
+		internationalTemplates.Lock()
+		internationalTemplates.types[key] = t
+		internationalTemplates.Unlock()
+	}
+	if t != TemplateTypeInternational {
+		return ErrTemplateNotInternational
+	}
+	return nil
+}
+
+// SendInternationalSms 发送国际/港澳台短信接口
+// businessID 设置业务请求流水号，必填。
+// phoneNumbers 短信发送的号码列表，必填。 E.164格式, 如+6591234567
+// signName 短信签名
+// templateCode 申请的国际/港澳台短信模板编码,必填
+// templateParam 短信模板变量参数
+func SendInternationalSms(businessID string, phoneNumbers []string, signName, templateCode, templateParam string) (*SendSmsRequest, error) {
+	r := SendSms(businessID, "", signName, templateCode, templateParam)
+	if err := r.SetInternationalPhoneNumbers(phoneNumbers...); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// globeSegments 解析短信计费条数
+func globeSegments(n *json.Number) int {
+	if n != nil {
+		i, _ := n.Int64()
+		return int(i)
+	}
+	return 0
+}
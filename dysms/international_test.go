@@ -0,0 +1,106 @@
+package dysms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_ParseE164PhoneNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		out  string
+		fail bool
+	}{
+		{"+6591234567", "6591234567", false},
+		{" +85212345678 ", "85212345678", false},
+		{"6591234567", "", true},
+		{"+65 9123 4567", "", true},
+		{"+0591234567", "", true},
+		{"+123", "", true},
+	}
+	for _, test := range tests {
+		n, err := ParseE164PhoneNumber(test.in)
+		if (err != nil) != test.fail || n != test.out {
+			t.Error("ParseE164PhoneNumber failed", test.in, n, err)
+		}
+	}
+}
+
+func Test_SendInternationalSms(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch q.Get("Action") {
+		case "QuerySmsTemplate":
+			if q.Get("TemplateCode") == "SMS_GLOBE" && q.Get("AccessKeyId") == "testId" {
+				w.Write([]byte(`{"RequestId":"r1","Code":"OK","TemplateCode":"SMS_GLOBE","TemplateType":3,"TemplateStatus":1}`))
+			} else {
+				w.Write([]byte(`{"RequestId":"r1","Code":"OK","TemplateCode":"SMS_LOCAL","TemplateType":1,"TemplateStatus":1}`))
+			}
+		case "SendSms":
+			if q.Get("PhoneNumbers") != "006591234567,0085212345678" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"RequestId":"r2","Code":"isv.MOBILE_NUMBER_ILLEGAL"}`))
+				return
+			}
+			w.Write([]byte(`{"RequestId":"r2","Code":"OK","BizId":"123^0"}`))
+		}
+	}))
+	defer ts.Close()
+	defer setTestEndPoint(ts.URL)()
+
+	r, err := SendInternationalSms("1", []string{"+6591234567", "+85212345678"}, "测试签名", "SMS_GLOBE", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := r.DoActionWithException()
+	if err != nil || resp.GetBizID() != "123^0" {
+		t.Error("SendInternationalSms failed", err)
+	}
+
+	r, _ = SendInternationalSms("1", []string{"+6591234567"}, "测试签名", "SMS_LOCAL", "")
+	if _, err = r.DoActionWithException(); err != ErrTemplateNotInternational {
+		t.Error("SendInternationalSms template check failed", err)
+	}
+
+	// 其他账号下相同的模板CODE不是国际模板, 使用该账号和服务地址校验
+	var endPoint string
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endPoint = "other"
+		ts.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer other.Close()
+	c := NewClient("otherId", "otherSecret")
+	c.SetEndPoint(other.URL)
+	r, _ = SendInternationalSms("1", []string{"+6591234567"}, "测试签名", "SMS_GLOBE", "")
+	r.Request.SetClient(c)
+	if _, err = r.DoActionWithException(); err != ErrTemplateNotInternational || endPoint != "other" {
+		t.Error("SendInternationalSms template check with client failed", err, endPoint)
+	}
+}
+
+func Test_SendMessageWithTemplate(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("Version") != "2018-05-01" || r.FormValue("RegionId") != "ap-southeast-1" || r.FormValue("To") != "6591234567" {
+			w.Write([]byte(`{"RequestId":"r1","ResponseCode":"InvalidParameter"}`))
+			return
+		}
+		w.Write([]byte(`{"RequestId":"r1","ResponseCode":"OK","ResponseDescription":"OK","Segments":"1","To":"6591234567","MessageId":"1008030300****","NumberDetail":{"Carrier":"SingTel","Country":"Singapore","Region":"Singapore"}}`))
+	}))
+	defer ts.Close()
+	endPoint := GlobeEndPoint
+	GlobeEndPoint = ts.URL
+	defer func() { GlobeEndPoint = endPoint }()
+
+	r, err := SendMessageWithTemplate("+6591234567", "Alicloud", "SMS_1", `{"code":"1234"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := r.DoActionWithException()
+	if err != nil {
+		t.Fatal(err, resp.String())
+	}
+	if resp.GetSegments() != 1 || resp.GetNumberDetail().Carrier != "SingTel" || resp.GetMessageID() != "1008030300****" {
+		t.Error("SendMessageWithTemplate response failed", resp.String())
+	}
+}
@@ -0,0 +1,198 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// TagResourceList 资源标签列表
+type TagResourceList struct {
+	TagResource []TagResource `json:"TagResource"`
+}
+
+// ListTagResourcesResponse 查询资源标签列表接口服务器响应
+type ListTagResourcesResponse struct {
+	ErrorMessage
+	NextToken    *string          `json:"NextToken,omitempty"`    // 下一页的查询凭证, 为空表示没有更多数据
+	TagResources *TagResourceList `json:"TagResources,omitempty"` // 资源标签列表
+}
+
+// GetNextToken 获取下一页的查询凭证
+func (l *ListTagResourcesResponse) GetNextToken() string {
+	if l != nil && l.NextToken != nil {
+		return *l.NextToken
+	}
+	return ""
+}
+
+// GetTagResources 获取资源标签列表
+func (l *ListTagResourcesResponse) GetTagResources() []TagResource {
+	if l != nil && l.TagResources != nil {
+		return l.TagResources.TagResource
+	}
+	return nil
+}
+
+// String 序列化成JSON字符串
+func (l ListTagResourcesResponse) String() string {
+	body, err := json.Marshal(l)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// ListTagResourcesRequest 查询资源标签列表接口请求
+type ListTagResourcesRequest struct {
+	Request *Request
+}
+
+// SetResourceType 设置资源类型 必须, 目前仅支持 ResourceTypeTemplate
+func (l *ListTagResourcesRequest) SetResourceType(resourceType string) {
+	if l != nil && l.Request != nil {
+		l.Request.Put("ResourceType", resourceType)
+	}
+}
+
+// GetResourceType 获取资源类型
+func (l *ListTagResourcesRequest) GetResourceType() string {
+	if l != nil && l.Request != nil {
+		return l.Request.Get("ResourceType")
+	}
+	return ""
+}
+
+// SetResourceIDs 设置资源ID列表, 即短信模板CODE
+func (l *ListTagResourcesRequest) SetResourceIDs(resourceIDs []string) {
+	if l != nil && l.Request != nil {
+		putResourceIDs(l.Request, resourceIDs)
+	}
+}
+
+// SetTags 设置过滤的标签
+func (l *ListTagResourcesRequest) SetTags(tags map[string]string) {
+	if l != nil && l.Request != nil {
+		putTags(l.Request, tags)
+	}
+}
+
+// SetNextToken 设置下一页的查询凭证
+func (l *ListTagResourcesRequest) SetNextToken(nextToken string) {
+	if l != nil && l.Request != nil {
+		l.Request.Put("NextToken", nextToken)
+	}
+}
+
+// GetNextToken 获取下一页的查询凭证
+func (l *ListTagResourcesRequest) GetNextToken() string {
+	if l != nil && l.Request != nil {
+		return l.Request.Get("NextToken")
+	}
+	return ""
+}
+
+// SetPageSize 设置每页显示条数, 最大值为50
+func (l *ListTagResourcesRequest) SetPageSize(pageSize int) {
+	if l != nil && l.Request != nil {
+		l.Request.Put("PageSize", strconv.Itoa(pageSize))
+	}
+}
+
+// GetPageSize 获取每页显示条数
+func (l *ListTagResourcesRequest) GetPageSize() int {
+	if l != nil && l.Request != nil {
+		n, _ := strconv.Atoi(l.Request.Get("PageSize"))
+		return n
+	}
+	return 0
+}
+
+// DoActionWithException 发起HTTP请求
+func (l *ListTagResourcesRequest) DoActionWithException() (resp *ListTagResourcesResponse, err error) {
+	if l != nil && l.Request != nil {
+		resp := &ListTagResourcesResponse{}
+		body, httpCode, err := l.Request.Do("ListTagResources")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("ListTagResourcesRequest is nil")
+}
+
+// ListTagResources 查询短信模板标签列表接口
+// resourceIDs 短信模板CODE列表
+// tags 过滤的标签
+func ListTagResources(resourceIDs []string, tags map[string]string) *ListTagResourcesRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "ListTagResources")
+	req.Put("ProdCode", tagProdCode)
+
+	r := &ListTagResourcesRequest{Request: req}
+	r.SetResourceType(ResourceTypeTemplate) // 必填 - 资源类型
+	if len(resourceIDs) > 0 {
+		r.SetResourceIDs(resourceIDs) // 短信模板CODE列表
+	}
+	if len(tags) > 0 {
+		r.SetTags(tags) // 过滤的标签
+	}
+	return r
+}
+
+// ListTagResourcesPaginator 基于 NextToken 的资源标签列表分页器
+type ListTagResourcesPaginator struct {
+	request   *ListTagResourcesRequest
+	nextToken string
+	started   bool
+}
+
+// NewListTagResourcesPaginator 创建一个资源标签列表分页器
+func NewListTagResourcesPaginator(request *ListTagResourcesRequest) *ListTagResourcesPaginator {
+	return &ListTagResourcesPaginator{request: request}
+}
+
+// HasNextPage 是否还有下一页
+func (p *ListTagResourcesPaginator) HasNextPage() bool {
+	return !p.started || p.nextToken != ""
+}
+
+// NextPage 获取下一页的资源标签列表
+func (p *ListTagResourcesPaginator) NextPage() ([]TagResource, error) {
+	if !p.HasNextPage() {
+		return nil, errors.New("no more pages")
+	}
+	if p.nextToken != "" {
+		p.request.SetNextToken(p.nextToken)
+	}
+	resp, err := p.request.DoActionWithException()
+	if err != nil {
+		return nil, err
+	}
+	p.started = true
+	p.nextToken = resp.GetNextToken()
+	return resp.GetTagResources(), nil
+}
+
+// All 依次获取全部的资源标签
+func (p *ListTagResourcesPaginator) All() ([]TagResource, error) {
+	var all []TagResource
+	for p.HasNextPage() {
+		tags, err := p.NextPage()
+		if err != nil {
+			return all, err
+		}
+		all = append(all, tags...)
+	}
+	return all, nil
+}
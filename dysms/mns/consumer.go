@@ -0,0 +1,172 @@
+// Package mns Copyright 2016 The GiterLab Authors. All rights reserved.
+//
+// mns 通过消息服务(MNS)队列消费短信发送状态报告(SmsReport)和上行短信(SmsUp)
+package mns
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms/push"
+)
+
+// 默认参数
+const (
+	DefaultWaitSeconds = 10               // 默认长轮询等待时间, 秒
+	DefaultMinBackoff  = time.Second      // 默认的最小退避时间
+	DefaultMaxBackoff  = 60 * time.Second // 默认的最大退避时间
+)
+
+// Consumer 消息队列消费者, 消息处理成功后才会从队列中删除, 处理失败的消息会在可见性超时后重新被消费
+type Consumer struct {
+	MessageType string        // 消息类型, dysms.MessageTypeSmsReport 或 dysms.MessageTypeSmsUp
+	QueueName   string        // 队列名称, 如 Alicom-Queue-******-SmsReport
+	EndPoint    string        // MNS服务地址, 如 https://1943695596114318.mns.cn-hangzhou.aliyuncs.com/
+	WaitSeconds int           // 长轮询等待时间, 秒, 取值1~30
+	MinBackoff  time.Duration // 出错时的最小退避时间
+	MaxBackoff  time.Duration // 出错时的最大退避时间
+	HTTPClient  *http.Client  // 访问MNS使用的HTTP客户端
+	OnError     func(error)   // 出错时的通知, 可用于记录日志
+
+	handle func(body []byte) error
+	token  *token
+}
+
+// NewSmsReportConsumer 创建一个短信发送状态报告队列消费者
+func NewSmsReportConsumer(endPoint, queueName string, handler func(report *push.SmsReport) error) *Consumer {
+	c := newConsumer(dysms.MessageTypeSmsReport, endPoint, queueName)
+	c.handle = func(body []byte) error {
+		report := &push.SmsReport{}
+		if err := json.Unmarshal(body, report); err != nil {
+			return err
+		}
+		return handler(report)
+	}
+	return c
+}
+
+// NewSmsUpConsumer 创建一个上行短信队列消费者
+func NewSmsUpConsumer(endPoint, queueName string, handler func(up *push.SmsUp) error) *Consumer {
+	c := newConsumer(dysms.MessageTypeSmsUp, endPoint, queueName)
+	c.handle = func(body []byte) error {
+		up := &push.SmsUp{}
+		if err := json.Unmarshal(body, up); err != nil {
+			return err
+		}
+		return handler(up)
+	}
+	return c
+}
+
+func newConsumer(messageType, endPoint, queueName string) *Consumer {
+	return &Consumer{
+		MessageType: messageType,
+		QueueName:   queueName,
+		EndPoint:    endPoint,
+		WaitSeconds: DefaultWaitSeconds,
+		MinBackoff:  DefaultMinBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+		HTTPClient:  &http.Client{Timeout: time.Duration(DefaultWaitSeconds+30) * time.Second},
+	}
+}
+
+// Run 持续消费队列中的消息, 直到 ctx 被取消
+func (c *Consumer) Run(ctx context.Context) error {
+	backoff := time.Duration(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err := c.poll(); err != nil {
+			if err != errMessageNotExist {
+				c.notify(err)
+				backoff = c.nextBackoff(backoff)
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(backoff):
+				}
+			} else if c.WaitSeconds <= 0 {
+				// 非长轮询模式下队列为空时稍作等待, 避免空转
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(c.minBackoff()):
+				}
+			}
+			continue
+		}
+		backoff = 0
+	}
+}
+
+// poll 接收并处理一条消息
+func (c *Consumer) poll() error {
+	if c.token.expired(time.Now()) {
+		t, err := queryToken(c.MessageType, c.QueueName)
+		if err != nil {
+			return err
+		}
+		c.token = t
+	}
+	q := &queueClient{httpClient: c.httpClient(), endPoint: c.EndPoint, queueName: c.QueueName, token: c.token}
+	m, err := q.receiveMessage(c.WaitSeconds)
+	if err != nil {
+		return err
+	}
+	if err = c.handle(decodeBody(m.MessageBody)); err != nil {
+		return err
+	}
+	return q.deleteMessage(m.ReceiptHandle)
+}
+
+// decodeBody 消息正文为base64编码的JSON, 解码失败时按原文处理
+func decodeBody(body string) []byte {
+	b, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return []byte(body)
+	}
+	return b
+}
+
+func (c *Consumer) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *Consumer) notify(err error) {
+	if c.OnError != nil {
+		c.OnError(err)
+	}
+}
+
+func (c *Consumer) minBackoff() time.Duration {
+	if c.MinBackoff > 0 {
+		return c.MinBackoff
+	}
+	return DefaultMinBackoff
+}
+
+// nextBackoff 指数退避
+func (c *Consumer) nextBackoff(backoff time.Duration) time.Duration {
+	maxBackoff := c.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+	if backoff <= 0 {
+		return c.minBackoff()
+	}
+	backoff *= 2
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
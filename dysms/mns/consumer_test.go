@@ -0,0 +1,82 @@
+package mns
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms/push"
+)
+
+func Test_signatureMethod(t *testing.T) {
+	header := http.Header{}
+	header.Set("Date", "Thu, 17 Mar 2012 18:49:58 GMT")
+	header.Set("x-mns-version", "2015-06-06")
+	header.Set("Content-Type", "text/xml")
+	// StringToSign: "GET\n\ntext/xml\nThu, 17 Mar 2012 18:49:58 GMT\nx-mns-version:2015-06-06\n/queues/q/messages"
+	if signatureMethod("secret", "GET", "/queues/q/messages", header) != "QflF/QwvO108jj6VIDLvdc1LAd8=" {
+		t.Error("signatureMethod failed", signatureMethod("secret", "GET", "/queues/q/messages", header))
+	}
+}
+
+func Test_Consumer(t *testing.T) {
+	body := base64.StdEncoding.EncodeToString([]byte(`{"phone_number":"1381111****","send_time":"2017-01-01 00:00:00","report_time":"2017-01-01 00:00:05","success":true,"err_code":"DELIVERED","err_msg":"用户接收成功","sms_size":"1","biz_id":"12345","out_id":"67890"}`))
+	tokens, deleted := 0, 0
+	dybase := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("Action") != "QueryTokenForMnsQueue" || r.URL.Query().Get("MessageType") != "SmsReport" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		tokens++
+		w.Write([]byte(`{"RequestId":"r1","Code":"OK","MessageTokenDTO":{"AccessKeyId":"tmpId","AccessKeySecret":"tmpSecret","SecurityToken":"tmpToken","CreateTime":"2017-01-01 00:00:00","ExpireTime":"2099-01-01 00:00:00"}}`))
+	}))
+	defer dybase.Close()
+	mns := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource := r.URL.RequestURI()
+		if r.Header.Get("x-mns-security-token") != "tmpToken" || r.Header.Get("Authorization") != "MNS tmpId:"+signatureMethod("tmpSecret", r.Method, resource, r.Header) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch {
+		case r.Method == "GET" && resource == "/queues/Alicom-Queue-1-SmsReport/messages?waitseconds=1":
+			w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Message xmlns="http://mns.aliyuncs.com/doc/v1/"><MessageId>m1</MessageId><ReceiptHandle>h1</ReceiptHandle><MessageBody>` + body + `</MessageBody><DequeueCount>1</DequeueCount></Message>`))
+		case r.Method == "DELETE" && strings.HasSuffix(resource, "?ReceiptHandle=h1"):
+			deleted++
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer mns.Close()
+
+	endPoint := dysms.DybaseEndPoint
+	dysms.DybaseEndPoint = dybase.URL
+	defer func() { dysms.DybaseEndPoint = endPoint }()
+	dysms.SetACLClient("testId", "testSecret")
+
+	fail := true
+	var reports []*push.SmsReport
+	c := NewSmsReportConsumer(mns.URL, "Alicom-Queue-1-SmsReport", func(report *push.SmsReport) error {
+		if fail {
+			return errors.New("handler failed")
+		}
+		reports = append(reports, report)
+		return nil
+	})
+	c.WaitSeconds = 1
+
+	if err := c.poll(); err == nil || deleted != 0 {
+		t.Error("Consumer should not delete message when handler failed", err, deleted)
+	}
+	fail = false
+	if err := c.poll(); err != nil {
+		t.Fatal(err)
+	}
+	if deleted != 1 || tokens != 1 || len(reports) != 1 || reports[0].BizID != "12345" || !reports[0].Success {
+		t.Error("Consumer failed", deleted, tokens, len(reports))
+	}
+}
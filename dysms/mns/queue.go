@@ -0,0 +1,121 @@
+// Package mns Copyright 2016 The GiterLab Authors. All rights reserved.
+package mns
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// mnsVersion MNS API版本
+const mnsVersion = "2015-06-06"
+
+// errMessageNotExist 队列中没有可消费的消息
+var errMessageNotExist = errors.New("MessageNotExist")
+
+// Message 队列中的一条消息
+type Message struct {
+	MessageID        string `xml:"MessageId"`        // 消息ID
+	ReceiptHandle    string `xml:"ReceiptHandle"`    // 消息句柄, 删除消息时使用
+	MessageBody      string `xml:"MessageBody"`      // 消息正文
+	MessageBodyMD5   string `xml:"MessageBodyMD5"`   // 消息正文的MD5
+	EnqueueTime      int64  `xml:"EnqueueTime"`      // 消息发送到队列的时间, 毫秒
+	FirstDequeueTime int64  `xml:"FirstDequeueTime"` // 第一次被消费的时间, 毫秒
+	DequeueCount     int    `xml:"DequeueCount"`     // 总共被消费的次数
+}
+
+// ErrorMessage MNS服务器返回的错误信息
+type ErrorMessage struct {
+	HTTPCode  int    `xml:"-"`
+	Code      string `xml:"Code"`
+	Message   string `xml:"Message"`
+	RequestID string `xml:"RequestId"`
+	HostID    string `xml:"HostId"`
+}
+
+// Error 错误描述
+func (e *ErrorMessage) Error() string {
+	return "mns: " + strconv.Itoa(e.HTTPCode) + " " + e.Code + ": " + e.Message
+}
+
+// queueClient 使用临时访问凭证访问一个MNS队列
+type queueClient struct {
+	httpClient *http.Client
+	endPoint   string
+	queueName  string
+	token      *token
+}
+
+// do 发送一个已签名的MNS请求
+func (q *queueClient) do(method, resource string) (*http.Response, error) {
+	req, err := http.NewRequest(method, strings.TrimRight(q.endPoint, "/")+resource, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-mns-version", mnsVersion)
+	if q.token.SecurityToken != "" {
+		req.Header.Set("x-mns-security-token", q.token.SecurityToken)
+	}
+	req.Header.Set("Authorization", "MNS "+q.token.AccessKeyID+":"+signatureMethod(q.token.AccessKeySecret, method, resource, req.Header))
+	return q.httpClient.Do(req)
+}
+
+// receiveMessage 长轮询接收一条消息, 队列中没有消息时返回 errMessageNotExist
+func (q *queueClient) receiveMessage(waitSeconds int) (*Message, error) {
+	resource := "/queues/" + url.PathEscape(q.queueName) + "/messages"
+	if waitSeconds > 0 {
+		resource += "?waitseconds=" + strconv.Itoa(waitSeconds)
+	}
+	resp, err := q.do("GET", resource)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		e := parseError(resp.StatusCode, body)
+		if e.Code == "MessageNotExist" {
+			return nil, errMessageNotExist
+		}
+		return nil, e
+	}
+	m := &Message{}
+	if err = xml.Unmarshal(body, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// deleteMessage 删除一条已消费的消息
+func (q *queueClient) deleteMessage(receiptHandle string) error {
+	resource := "/queues/" + url.PathEscape(q.queueName) + "/messages?ReceiptHandle=" + url.QueryEscape(receiptHandle)
+	resp, err := q.do("DELETE", resource)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		return parseError(resp.StatusCode, body)
+	}
+	return nil
+}
+
+// parseError 解析MNS错误应答
+func parseError(httpCode int, body []byte) *ErrorMessage {
+	e := &ErrorMessage{HTTPCode: httpCode}
+	if err := xml.Unmarshal(body, e); err != nil {
+		e.Message = string(body)
+	}
+	return e
+}
@@ -0,0 +1,33 @@
+// Package mns Copyright 2016 The GiterLab Authors. All rights reserved.
+package mns
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// 计算MNS请求签名。
+// 签名字符串为 VERB、CONTENT-MD5、CONTENT-TYPE、DATE 以换行符连接, 再加上 CanonicalizedMNSHeaders 和 CanonicalizedResource,
+// 使用临时 AccessKeySecret 计算 HMAC-SHA1 后按 Base64 编码即为签名值
+func signatureMethod(accessKeySecret, method, resource string, header http.Header) string {
+	var keys []string
+	for k := range header {
+		if strings.HasPrefix(strings.ToLower(k), "x-mns-") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return strings.ToLower(keys[i]) < strings.ToLower(keys[j]) })
+	mnsHeaders := ""
+	for _, k := range keys {
+		mnsHeaders += strings.ToLower(k) + ":" + header.Get(k) + "\n"
+	}
+	stringToSign := method + "\n" + header.Get("Content-MD5") + "\n" + header.Get("Content-Type") + "\n" + header.Get("Date") + "\n" + mnsHeaders + resource
+
+	mac := hmac.New(sha1.New, []byte(accessKeySecret))
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,43 @@
+// Package mns Copyright 2016 The GiterLab Authors. All rights reserved.
+package mns
+
+import (
+	"errors"
+	"time"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+)
+
+// tokenRenewAhead 临时访问凭证提前更新的时间
+const tokenRenewAhead = 2 * time.Minute
+
+// token 消息队列的临时访问凭证
+type token struct {
+	dysms.MessageTokenDTO
+	expireTime time.Time
+}
+
+// expired 临时访问凭证是否即将过期
+func (t *token) expired(now time.Time) bool {
+	return t == nil || !now.Add(tokenRenewAhead).Before(t.expireTime)
+}
+
+// queryToken 通过 dybaseapi 获取临时访问凭证, 复用 dysms 的请求签名
+func queryToken(messageType, queueName string) (*token, error) {
+	resp, err := dysms.QueryTokenForMnsQueue(messageType, queueName).DoActionWithException()
+	if err != nil {
+		return nil, err
+	}
+	dto := resp.GetMessageTokenDTO()
+	if dto == nil {
+		if resp.GetCode() != "" {
+			return nil, errors.New(resp.GetCode())
+		}
+		return nil, errors.New("MessageTokenDTO is nil")
+	}
+	expireTime, err := dto.GetExpireTime()
+	if err != nil {
+		return nil, err
+	}
+	return &token{MessageTokenDTO: *dto, expireTime: expireTime}, nil
+}
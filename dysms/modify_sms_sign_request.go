@@ -0,0 +1,131 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// ModifySmsSignResponse 修改短信签名接口服务器响应
+type ModifySmsSignResponse struct {
+	ErrorMessage
+	SignName *string `json:"SignName,omitempty"` // 签名名称
+}
+
+// GetSignName 获取签名名称
+func (m *ModifySmsSignResponse) GetSignName() string {
+	if m != nil && m.SignName != nil {
+		return *m.SignName
+	}
+	return ""
+}
+
+// String 序列化成JSON字符串
+func (m ModifySmsSignResponse) String() string {
+	body, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// ModifySmsSignRequest 修改短信签名接口请求
+type ModifySmsSignRequest struct {
+	Request *Request
+}
+
+// SetSignName 设置签名名称 必须
+func (m *ModifySmsSignRequest) SetSignName(signName string) {
+	if m != nil && m.Request != nil {
+		m.Request.Put("SignName", signName)
+	}
+}
+
+// GetSignName 获取签名名称
+func (m *ModifySmsSignRequest) GetSignName() string {
+	if m != nil && m.Request != nil {
+		return m.Request.Get("SignName")
+	}
+	return ""
+}
+
+// SetSignSource 设置签名来源 必须
+func (m *ModifySmsSignRequest) SetSignSource(signSource SignSource) {
+	if m != nil && m.Request != nil {
+		m.Request.Put("SignSource", strconv.Itoa(int(signSource)))
+	}
+}
+
+// GetSignSource 获取签名来源
+func (m *ModifySmsSignRequest) GetSignSource() SignSource {
+	if m != nil && m.Request != nil {
+		n, _ := strconv.Atoi(m.Request.Get("SignSource"))
+		return SignSource(n)
+	}
+	return 0
+}
+
+// SetRemark 设置短信签名申请说明 必须
+// 请在申请说明中详细描述您的业务使用场景, 申请工信部备案网站的全称或简称请在此处填写域名
+func (m *ModifySmsSignRequest) SetRemark(remark string) {
+	if m != nil && m.Request != nil {
+		m.Request.Put("Remark", remark)
+	}
+}
+
+// GetRemark 获取短信签名申请说明
+func (m *ModifySmsSignRequest) GetRemark() string {
+	if m != nil && m.Request != nil {
+		return m.Request.Get("Remark")
+	}
+	return ""
+}
+
+// SetSignFileList 设置签名的资质证明文件列表
+func (m *ModifySmsSignRequest) SetSignFileList(signFileList []SignFile) {
+	if m != nil && m.Request != nil {
+		putSignFileList(m.Request, signFileList)
+	}
+}
+
+// DoActionWithException 发起HTTP请求
+func (m *ModifySmsSignRequest) DoActionWithException() (resp *ModifySmsSignResponse, err error) {
+	if m != nil && m.Request != nil {
+		resp := &ModifySmsSignResponse{}
+		body, httpCode, err := m.Request.DoPost("ModifySmsSign")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("ModifySmsSignRequest is nil")
+}
+
+// ModifySmsSign 修改短信签名接口, 仅支持修改审核未通过的签名, 修改后会重新提交审核
+// signName 必填 - 签名名称
+// signSource 必填 - 签名来源
+// remark 必填 - 短信签名申请说明
+// signFileList 可选 - 签名的资质证明文件列表
+func ModifySmsSign(signName string, signSource SignSource, remark string, signFileList ...SignFile) *ModifySmsSignRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "ModifySmsSign")
+
+	r := &ModifySmsSignRequest{Request: req}
+	r.SetSignName(signName)     // 必填 - 签名名称
+	r.SetSignSource(signSource) // 必填 - 签名来源
+	r.SetRemark(remark)         // 必填 - 短信签名申请说明
+	if len(signFileList) > 0 {
+		r.SetSignFileList(signFileList) // 可选 - 签名的资质证明文件列表
+	}
+	return r
+}
@@ -0,0 +1,188 @@
+// Package otp Copyright 2016 The GiterLab Authors. All rights reserved.
+package otp
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+	"github.com/GiterLab/aliyun-sms-go-sdk/phone"
+)
+
+// 默认的限流参数
+const (
+	DefaultIPLimit     = 30               // 每个IP在 DefaultIPWindow 内最多请求次数
+	DefaultIPWindow    = 10 * time.Minute // IP限流时间窗口
+	DefaultPhoneLimit  = 5                // 每个号码在 DefaultPhoneWindow 内最多发送次数
+	DefaultPhoneWindow = time.Hour        // 号码限流时间窗口
+	defaultMaxBodySize = 1 << 16          // 请求体大小上限
+)
+
+// 应答错误码
+const (
+	CodeOK            = "OK"
+	CodeBadRequest    = "BAD_REQUEST"    // 请求格式错误或号码格式错误
+	CodeCaptcha       = "CAPTCHA_FAILED" // 人机验证失败
+	CodeRateLimited   = "RATE_LIMITED"   // 请求过于频繁
+	CodeInvalidCode   = "INVALID_CODE"   // 验证码错误、过期或不存在
+	CodeInternalError = "INTERNAL_ERROR" // 发送失败或存储出错
+)
+
+// HandlerRequest 验证码接口请求体
+type HandlerRequest struct {
+	Phone   string `json:"phone"`             // 手机号码
+	Scene   string `json:"scene"`             // 场景, 如login、register
+	Code    string `json:"code,omitempty"`    // 验证码, 校验时必填
+	Captcha string `json:"captcha,omitempty"` // 人机验证凭证, 由 VerifyCaptcha 校验
+}
+
+// HandlerResponse 验证码接口应答, 所有错误都使用相同的格式
+type HandlerResponse struct {
+	Code     string `json:"code"`               // 错误码, 成功时为OK
+	Message  string `json:"message"`            // 描述
+	Cooldown int    `json:"cooldown,omitempty"` // 距离可以重新发送的秒数
+}
+
+// Handler 验证码HTTP接口, 提供 POST /otp/send 和 POST /otp/verify
+// 为避免泄露号码是否已注册等信息, 校验失败的原因统一为 CodeInvalidCode, 发送失败统一为 CodeInternalError
+type Handler struct {
+	Manager       *Manager                                   // 验证码管理器
+	Scenes        map[string]bool                            // 允许的场景, 其他场景返回 CodeBadRequest, 避免通过更换场景绕过冷却时间
+	IPLimiter     *RateLimiter                               // IP限流, 作用于发送和校验
+	PhoneLimiter  *RateLimiter                               // 号码限流, 作用于发送
+	VerifyCaptcha func(r *http.Request, captcha string) bool // 可选, 发送前的人机验证
+	ClientIP      func(r *http.Request) string               // 获取客户端IP, 为nil时使用 RemoteAddr
+	OnError       func(r *http.Request, err error)           // 可选, 出错时的通知, 可用于记录日志
+
+	mux *http.ServeMux
+}
+
+// NewHandler 创建一个验证码HTTP接口, 使用默认的限流参数
+// scenes 为允许的场景, 如login、register, 未列出的场景一律拒绝
+func NewHandler(m *Manager, scenes ...string) *Handler {
+	h := &Handler{
+		Manager:      m,
+		Scenes:       make(map[string]bool, len(scenes)),
+		IPLimiter:    NewRateLimiter(DefaultIPLimit, DefaultIPWindow),
+		PhoneLimiter: NewRateLimiter(DefaultPhoneLimit, DefaultPhoneWindow),
+	}
+	for _, scene := range scenes {
+		h.Scenes[scene] = true
+	}
+	h.mux = http.NewServeMux()
+	h.mux.HandleFunc("/otp/send", h.Send)
+	h.mux.HandleFunc("/otp/verify", h.Verify)
+	return h
+}
+
+// ServeHTTP 实现 http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// writeJSON 写入JSON应答
+func writeJSON(w http.ResponseWriter, httpCode int, resp HandlerResponse) {
+	body, _ := json.Marshal(resp)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(httpCode)
+	w.Write(body)
+}
+
+// clientIP 获取客户端IP
+func (h *Handler) clientIP(r *http.Request) string {
+	if h.ClientIP != nil {
+		return h.ClientIP(r)
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// error 通知并应答错误
+func (h *Handler) error(w http.ResponseWriter, r *http.Request, err error, httpCode int, resp HandlerResponse) {
+	if h.OnError != nil && err != nil {
+		h.OnError(r, err)
+	}
+	writeJSON(w, httpCode, resp)
+}
+
+// read 校验请求方法、IP限流, 解析请求体并将号码规范化为 SendSms 接口的格式
+func (h *Handler) read(w http.ResponseWriter, r *http.Request) (*HandlerRequest, bool) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, HandlerResponse{Code: CodeBadRequest, Message: "method not allowed"})
+		return nil, false
+	}
+	if !h.IPLimiter.Allow(h.clientIP(r)) {
+		writeJSON(w, http.StatusTooManyRequests, HandlerResponse{Code: CodeRateLimited, Message: "too many requests"})
+		return nil, false
+	}
+	req := &HandlerRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, defaultMaxBodySize)).Decode(req); err != nil {
+		writeJSON(w, http.StatusBadRequest, HandlerResponse{Code: CodeBadRequest, Message: "invalid request"})
+		return nil, false
+	}
+	n, err := phone.Parse(req.Phone)
+	if err != nil || !h.Scenes[req.Scene] {
+		writeJSON(w, http.StatusBadRequest, HandlerResponse{Code: CodeBadRequest, Message: "invalid phone or scene"})
+		return nil, false
+	}
+	req.Phone = dysms.FormatPhoneNumber(n)
+	return req, true
+}
+
+// Send 处理 POST /otp/send, 请求体为 {"phone":"...","scene":"...","captcha":"..."}
+func (h *Handler) Send(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.read(w, r)
+	if !ok {
+		return
+	}
+	if h.VerifyCaptcha != nil && !h.VerifyCaptcha(r, req.Captcha) {
+		writeJSON(w, http.StatusForbidden, HandlerResponse{Code: CodeCaptcha, Message: "captcha verification failed"})
+		return
+	}
+	if remaining := h.Manager.CooldownRemaining(req.Phone, req.Scene); remaining > 0 {
+		writeJSON(w, http.StatusTooManyRequests, HandlerResponse{Code: CodeRateLimited, Message: "too many requests", Cooldown: seconds(remaining)})
+		return
+	}
+	if !h.PhoneLimiter.Allow(req.Phone) {
+		writeJSON(w, http.StatusTooManyRequests, HandlerResponse{Code: CodeRateLimited, Message: "too many requests"})
+		return
+	}
+	switch err := h.Manager.Send(req.Phone, req.Scene); err {
+	case nil:
+		writeJSON(w, http.StatusOK, HandlerResponse{Code: CodeOK, Message: "sent", Cooldown: seconds(h.Manager.cooldown())})
+	case ErrCooldown:
+		// 并发请求都通过了冷却检查, 未发送的请求不占用号码限流次数
+		h.PhoneLimiter.Refund(req.Phone)
+		remaining := h.Manager.CooldownRemaining(req.Phone, req.Scene)
+		writeJSON(w, http.StatusTooManyRequests, HandlerResponse{Code: CodeRateLimited, Message: "too many requests", Cooldown: seconds(remaining)})
+	default:
+		h.error(w, r, err, http.StatusInternalServerError, HandlerResponse{Code: CodeInternalError, Message: "failed to send code"})
+	}
+}
+
+// Verify 处理 POST /otp/verify, 请求体为 {"phone":"...","scene":"...","code":"..."}
+func (h *Handler) Verify(w http.ResponseWriter, r *http.Request) {
+	req, ok := h.read(w, r)
+	if !ok {
+		return
+	}
+	switch err := h.Manager.Verify(req.Phone, req.Scene, req.Code); err {
+	case nil:
+		writeJSON(w, http.StatusOK, HandlerResponse{Code: CodeOK, Message: "verified"})
+	case ErrNotFound, ErrExpired, ErrMismatch, ErrTooManyAttempts:
+		writeJSON(w, http.StatusBadRequest, HandlerResponse{Code: CodeInvalidCode, Message: "invalid code"})
+	default:
+		h.error(w, r, err, http.StatusInternalServerError, HandlerResponse{Code: CodeInternalError, Message: "failed to verify code"})
+	}
+}
+
+// seconds 向上取整的秒数
+func seconds(d time.Duration) int {
+	return int((d + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,105 @@
+package otp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+)
+
+func post(t *testing.T, h http.Handler, path, body string) (int, HandlerResponse) {
+	req := httptest.NewRequest("POST", path, strings.NewReader(body))
+	req.RemoteAddr = "10.0.0.1:1234"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	var resp HandlerResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	return w.Code, resp
+}
+
+func Test_Handler(t *testing.T) {
+	// 本地的短信服务替身, 记录发送的验证码
+	var code, phoneNumbers string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var param map[string]string
+		json.Unmarshal([]byte(r.URL.Query().Get("TemplateParam")), &param)
+		code = param["code"]
+		phoneNumbers = r.URL.Query().Get("PhoneNumbers")
+		w.Write([]byte(`{"RequestId":"R","Code":"OK","Message":"OK","BizId":"B"}`))
+	}))
+	defer ts.Close()
+	// 使用独立的账号配置发送, 不修改 dysms 的默认账号
+	client := dysms.NewClient("testId", "testSecret")
+	client.SetEndPoint(ts.URL + "/")
+	m := New(NewMemoryStore(), "sign", "SMS_1")
+	m.SendFunc = func(phoneNumber, signName, templateCode, templateParam string) error {
+		r := dysms.SendSms("", phoneNumber, signName, templateCode, templateParam)
+		r.Request.SetClient(client)
+		_, err := r.DoActionWithException()
+		return err
+	}
+
+	h := NewHandler(m, "login", "register")
+	h.VerifyCaptcha = func(r *http.Request, captcha string) bool {
+		return captcha == "ok"
+	}
+	h.IPLimiter = NewRateLimiter(10, DefaultIPWindow)
+
+	if status, resp := post(t, h, "/otp/send", `{"phone":"13800000000","scene":"login"}`); status != 403 || resp.Code != CodeCaptcha {
+		t.Error("captcha check failed", status, resp)
+	}
+	if status, resp := post(t, h, "/otp/send", `{"phone":"123","scene":"login","captcha":"ok"}`); status != 400 || resp.Code != CodeBadRequest {
+		t.Error("phone check failed", status, resp)
+	}
+	status, resp := post(t, h, "/otp/send", `{"phone":"+86 138 0000 0000","scene":"login","captcha":"ok"}`)
+	if status != 200 || resp.Cooldown != 60 || len(code) != DefaultLength || phoneNumbers != "13800000000" {
+		t.Fatal("send failed", status, resp, code, phoneNumbers)
+	}
+	loginCode := code
+	// 国际号码按 SendSms 接口的格式发送
+	if status, resp := post(t, h, "/otp/send", `{"phone":"+65 9123 4567","scene":"login","captcha":"ok"}`); status != 200 || phoneNumbers != "006591234567" {
+		t.Error("international send failed", status, resp, phoneNumbers)
+	}
+	if status, resp := post(t, h, "/otp/send", `{"phone":"13800000000","scene":"login","captcha":"ok"}`); status != 429 || resp.Cooldown <= 0 {
+		t.Error("cooldown failed", status, resp)
+	}
+	// 更换为未允许的场景不能绕过冷却时间
+	if status, resp := post(t, h, "/otp/send", `{"phone":"13800000000","scene":"a","captcha":"ok"}`); status != 400 || resp.Code != CodeBadRequest {
+		t.Error("unknown scene should be rejected", status, resp)
+	}
+	if status, resp := post(t, h, "/otp/verify", `{"phone":"13800000000","scene":"b","code":"x"}`); status != 400 || resp.Code != CodeBadRequest {
+		t.Error("unknown scene should be rejected", status, resp)
+	}
+
+	// 验证码错误和号码不存在的应答完全相同
+	_, wrong := post(t, h, "/otp/verify", `{"phone":"13800000000","scene":"login","code":"x"}`)
+	_, unknown := post(t, h, "/otp/verify", `{"phone":"13900000000","scene":"login","code":"x"}`)
+	if wrong != unknown || wrong.Code != CodeInvalidCode {
+		t.Error("verify errors should be uniform", wrong, unknown)
+	}
+	if status, resp := post(t, h, "/otp/verify", `{"phone":"13800000000","scene":"login","code":"`+loginCode+`"}`); status != 200 || resp.Code != CodeOK {
+		t.Error("verify failed", status, resp)
+	}
+	if status, resp := post(t, h, "/otp/verify", `{}`); status != 429 || resp.Code != CodeRateLimited {
+		t.Error("ip rate limit failed", status, resp)
+	}
+}
+
+func Test_RateLimiterRefund(t *testing.T) {
+	l := NewRateLimiter(1, DefaultPhoneWindow)
+	if !l.Allow("a") || l.Allow("a") {
+		t.Fatal("Allow failed")
+	}
+	l.Refund("a")
+	if !l.Allow("a") {
+		t.Error("Refund failed")
+	}
+	l.Refund("b")
+	var nilLimiter *RateLimiter
+	nilLimiter.Refund("a")
+}
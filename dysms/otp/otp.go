@@ -0,0 +1,242 @@
+// Package otp Copyright 2016 The GiterLab Authors. All rights reserved.
+//
+// otp 基于短信验证码模板的验证码发送与校验, 只保存加盐后的验证码哈希值
+package otp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+)
+
+// 默认参数
+const (
+	DefaultLength      = 6                // 验证码长度
+	DefaultTTL         = 5 * time.Minute  // 验证码有效期
+	DefaultCooldown    = 60 * time.Second // 重新发送的最小间隔
+	DefaultMaxAttempts = 5                // 最大校验失败次数
+	DefaultCodeParam   = "code"           // 短信模板中验证码变量的名称
+)
+
+// 验证码错误
+var (
+	ErrCooldown        = errors.New("otp: resend too frequently")
+	ErrNotFound        = errors.New("otp: code not found")
+	ErrExpired         = errors.New("otp: code expired")
+	ErrTooManyAttempts = errors.New("otp: too many attempts")
+	ErrMismatch        = errors.New("otp: code mismatch")
+)
+
+// Record 保存的验证码记录
+type Record struct {
+	Hash     string    `json:"hash"`      // 加盐后的验证码哈希值
+	Salt     string    `json:"salt"`      // 盐
+	SentAt   time.Time `json:"sent_at"`   // 发送时间
+	ExpireAt time.Time `json:"expire_at"` // 过期时间
+	Attempts int       `json:"attempts"`  // 校验失败次数
+}
+
+// Store 验证码存储
+type Store interface {
+	Get(key string) (*Record, error) // 记录不存在时返回 ErrNotFound
+	Put(key string, r *Record) error
+	Delete(key string) error
+}
+
+// Manager 验证码管理器
+type Manager struct {
+	Store        Store         // 验证码存储
+	SignName     string        // 短信签名
+	TemplateCode string        // 验证码短信模板CODE
+	CodeParam    string        // 短信模板中验证码变量的名称, 为空时使用 DefaultCodeParam
+	Length       int           // 验证码长度, 为0时使用 DefaultLength
+	TTL          time.Duration // 验证码有效期, 为0时使用 DefaultTTL
+	Cooldown     time.Duration // 重新发送的最小间隔, 为0时使用 DefaultCooldown
+	MaxAttempts  int           // 最大校验失败次数, 为0时使用 DefaultMaxAttempts
+
+	// SendFunc 发送短信, 为nil时使用 dysms.SendSms
+	SendFunc func(phoneNumber, signName, templateCode, templateParam string) error
+
+	mu sync.Mutex
+}
+
+// New 创建一个验证码管理器
+func New(store Store, signName, templateCode string) *Manager {
+	return &Manager{Store: store, SignName: signName, TemplateCode: templateCode}
+}
+
+// sendSms 使用 dysms.SendSms 发送短信
+func sendSms(phoneNumber, signName, templateCode, templateParam string) error {
+	resp, err := dysms.SendSms("", phoneNumber, signName, templateCode, templateParam).DoActionWithException()
+	if err != nil {
+		return err
+	}
+	if resp.GetCode() != "OK" {
+		return &resp.ErrorMessage
+	}
+	return nil
+}
+
+// key 存储键
+func key(phoneNumber, scene string) string {
+	return scene + ":" + phoneNumber
+}
+
+// hash 加盐计算验证码哈希值
+func hash(salt, code string) string {
+	sum := sha256.Sum256([]byte(salt + code))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateCode 使用crypto/rand生成指定长度的数字验证码
+func GenerateCode(length int) (string, error) {
+	code := make([]byte, length)
+	ten := big.NewInt(10)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, ten)
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+	return string(code), nil
+}
+
+// Send 生成并发送验证码, 同一号码同一场景在冷却时间内重复发送返回 ErrCooldown
+// 发送短信时不持有锁, 一个号码的发送请求阻塞不会影响其他号码的发送和校验
+func (m *Manager) Send(phoneNumber, scene string) error {
+	code, err := GenerateCode(m.length())
+	if err != nil {
+		return err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	param, err := dysms.MarshalTemplateParam(map[string]string{m.codeParam(): code})
+	if err != nil {
+		return err
+	}
+
+	k := key(phoneNumber, scene)
+	now := time.Now()
+	r := &Record{Salt: hex.EncodeToString(salt), SentAt: now, ExpireAt: now.Add(m.ttl())}
+	r.Hash = hash(r.Salt, code)
+
+	// 冷却检查和写入新记录在锁内完成, 冷却期内的并发发送只有一个能成功
+	m.mu.Lock()
+	old, err := m.Store.Get(k)
+	if err != nil && err != ErrNotFound {
+		m.mu.Unlock()
+		return err
+	}
+	if old != nil && now.Before(old.SentAt.Add(m.cooldown())) {
+		m.mu.Unlock()
+		return ErrCooldown
+	}
+	err = m.Store.Put(k, r)
+	m.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	send := m.SendFunc
+	if send == nil {
+		send = sendSms
+	}
+	if err := send(phoneNumber, m.SignName, m.TemplateCode, param); err != nil {
+		// 发送失败时恢复原记录, 记录已被替换时不做处理
+		m.mu.Lock()
+		if cur, gerr := m.Store.Get(k); gerr == nil && cur.Hash == r.Hash {
+			if old != nil {
+				m.Store.Put(k, old)
+			} else {
+				m.Store.Delete(k)
+			}
+		}
+		m.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// CooldownRemaining 距离可以重新发送验证码的剩余时间
+func (m *Manager) CooldownRemaining(phoneNumber, scene string) time.Duration {
+	r, err := m.Store.Get(key(phoneNumber, scene))
+	if err != nil {
+		return 0
+	}
+	if d := time.Until(r.SentAt.Add(m.cooldown())); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// Verify 校验验证码, 校验成功后验证码失效
+func (m *Manager) Verify(phoneNumber, scene, code string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k := key(phoneNumber, scene)
+	r, err := m.Store.Get(k)
+	if err != nil {
+		return err
+	}
+	if time.Now().After(r.ExpireAt) {
+		m.Store.Delete(k)
+		return ErrExpired
+	}
+	if r.Attempts >= m.maxAttempts() {
+		return ErrTooManyAttempts
+	}
+	if subtle.ConstantTimeCompare([]byte(hash(r.Salt, code)), []byte(r.Hash)) != 1 {
+		r.Attempts++
+		if err := m.Store.Put(k, r); err != nil {
+			return err
+		}
+		return ErrMismatch
+	}
+	return m.Store.Delete(k)
+}
+
+func (m *Manager) codeParam() string {
+	if m.CodeParam != "" {
+		return m.CodeParam
+	}
+	return DefaultCodeParam
+}
+
+func (m *Manager) length() int {
+	if m.Length > 0 {
+		return m.Length
+	}
+	return DefaultLength
+}
+
+func (m *Manager) ttl() time.Duration {
+	if m.TTL > 0 {
+		return m.TTL
+	}
+	return DefaultTTL
+}
+
+func (m *Manager) cooldown() time.Duration {
+	if m.Cooldown > 0 {
+		return m.Cooldown
+	}
+	return DefaultCooldown
+}
+
+func (m *Manager) maxAttempts() int {
+	if m.MaxAttempts > 0 {
+		return m.MaxAttempts
+	}
+	return DefaultMaxAttempts
+}
@@ -0,0 +1,148 @@
+package otp
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_GenerateCode(t *testing.T) {
+	code, err := GenerateCode(8)
+	if err != nil || len(code) != 8 || strings.Trim(code, "0123456789") != "" {
+		t.Error("GenerateCode failed", code, err)
+	}
+}
+
+func testManager(t *testing.T, store Store) {
+	var sent map[string]string
+	m := New(store, "sign", "SMS_1")
+	m.SendFunc = func(phoneNumber, signName, templateCode, templateParam string) error {
+		if phoneNumber == "13800000009" {
+			return errors.New("send failed")
+		}
+		sent = nil
+		return json.Unmarshal([]byte(templateParam), &sent)
+	}
+	m.MaxAttempts = 2
+
+	if err := m.Send("13800000000", "login"); err != nil {
+		t.Fatal(err)
+	}
+	code := sent["code"]
+	if len(code) != DefaultLength {
+		t.Fatal("Send failed", sent)
+	}
+	if err := m.Send("13800000000", "login"); err != ErrCooldown {
+		t.Error("Send should enforce cooldown", err)
+	}
+	if r, _ := store.Get("login:13800000000"); r == nil || strings.Contains(r.Hash, code) || r.Salt == "" {
+		t.Error("Store should only keep salted hash", r)
+	}
+	if err := m.Verify("13800000000", "register", code); err != ErrNotFound {
+		t.Error("Verify should be scoped by scene", err)
+	}
+	if err := m.Verify("13800000000", "login", code); err != nil {
+		t.Error("Verify failed", err)
+	}
+	if err := m.Verify("13800000000", "login", code); err != ErrNotFound {
+		t.Error("code should be used only once", err)
+	}
+
+	m.Cooldown = time.Nanosecond
+	m.Send("13800000000", "login")
+	m.Verify("13800000000", "login", "x")
+	m.Verify("13800000000", "login", "x")
+	if err := m.Verify("13800000000", "login", sent["code"]); err != ErrTooManyAttempts {
+		t.Error("Verify should enforce max attempts", err)
+	}
+
+	if err := m.Send("13800000009", "login"); err == nil {
+		t.Error("Send should fail")
+	}
+	if _, err := store.Get("login:13800000009"); err != ErrNotFound {
+		t.Error("failed send should not keep record", err)
+	}
+
+	m.TTL = time.Nanosecond
+	m.Send("13800000001", "login")
+	time.Sleep(time.Millisecond)
+	if err := m.Verify("13800000001", "login", sent["code"]); err != ErrExpired {
+		t.Error("Verify should enforce expiry", err)
+	}
+}
+
+func Test_MemoryStore(t *testing.T) {
+	testManager(t, NewMemoryStore())
+}
+
+func Test_FileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "otp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "otp.json")
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testManager(t, store)
+
+	store.Put("login:13800000002", &Record{Hash: "h", ExpireAt: time.Now().Add(time.Minute)})
+	store, err = NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, err := store.Get("login:13800000002"); err != nil || r.Hash != "h" {
+		t.Error("FileStore reload failed", r, err)
+	}
+}
+
+func Test_SendDoesNotBlockVerify(t *testing.T) {
+	release := make(chan struct{})
+	var code string
+	m := New(NewMemoryStore(), "sign", "SMS_1")
+	m.SendFunc = func(phoneNumber, signName, templateCode, templateParam string) error {
+		if phoneNumber == "13800000009" {
+			<-release
+			return nil
+		}
+		var sent map[string]string
+		json.Unmarshal([]byte(templateParam), &sent)
+		code = sent["code"]
+		return nil
+	}
+	if err := m.Send("13800000000", "login"); err != nil {
+		t.Fatal(err)
+	}
+
+	blocked := make(chan error)
+	go func() {
+		blocked <- m.Send("13800000009", "login")
+	}()
+	verified := make(chan error)
+	go func() {
+		// 等待另一个号码的发送进入阻塞状态
+		for m.CooldownRemaining("13800000009", "login") == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		verified <- m.Verify("13800000000", "login", code)
+	}()
+	select {
+	case err := <-verified:
+		if err != nil {
+			t.Error("Verify failed", err)
+		}
+	case <-time.After(time.Second):
+		t.Error("Verify blocked by a pending Send")
+	}
+	close(release)
+	if err := <-blocked; err != nil {
+		t.Error("Send failed", err)
+	}
+}
@@ -0,0 +1,69 @@
+// Package otp Copyright 2016 The GiterLab Authors. All rights reserved.
+package otp
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter 基于固定时间窗口的内存限流器, 可安全地并发使用
+type RateLimiter struct {
+	Limit  int           // 每个时间窗口内允许的请求数
+	Window time.Duration // 时间窗口
+
+	mu      sync.Mutex
+	windows map[string]*rateWindow
+}
+
+// rateWindow 某个键在当前时间窗口内的请求数
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// NewRateLimiter 创建一个限流器
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{Limit: limit, Window: window}
+}
+
+// Allow 记录一次请求, 超过限制时返回false
+func (l *RateLimiter) Allow(key string) bool {
+	if l == nil || l.Limit <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if l.windows == nil {
+		l.windows = make(map[string]*rateWindow)
+	}
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.Window {
+		if len(l.windows) >= 10000 {
+			for k, w := range l.windows {
+				if now.Sub(w.start) >= l.Window {
+					delete(l.windows, k)
+				}
+			}
+		}
+		w = &rateWindow{start: now}
+		l.windows[key] = w
+	}
+	if w.count >= l.Limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// Refund 退还一次已记录的请求, 用于请求被放行但最终没有执行的情况
+func (l *RateLimiter) Refund(key string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if w, ok := l.windows[key]; ok && w.count > 0 {
+		w.count--
+	}
+}
@@ -0,0 +1,139 @@
+// Package otp Copyright 2016 The GiterLab Authors. All rights reserved.
+package otp
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// MemoryStore 基于内存的验证码存储
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+}
+
+// NewMemoryStore 创建一个基于内存的验证码存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]Record)}
+}
+
+// Get 获取验证码记录
+func (s *MemoryStore) Get(key string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.records[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &r, nil
+}
+
+// Put 保存验证码记录, 同时清理已过期的记录
+func (s *MemoryStore) Put(key string, r *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.records == nil {
+		s.records = make(map[string]Record)
+	}
+	purge(s.records)
+	s.records[key] = *r
+	return nil
+}
+
+// Delete 删除验证码记录
+func (s *MemoryStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// purge 清理已过期的记录
+func purge(records map[string]Record) {
+	now := time.Now()
+	for k, r := range records {
+		if now.After(r.ExpireAt) {
+			delete(records, k)
+		}
+	}
+}
+
+// FileStore 基于JSON文件的验证码存储, 每次变更都会原子地重写整个文件
+type FileStore struct {
+	mu      sync.Mutex
+	path    string
+	records map[string]Record
+}
+
+// NewFileStore 打开或创建基于JSON文件的验证码存储
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, records: make(map[string]Record)}
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &s.records); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Get 获取验证码记录
+func (s *FileStore) Get(key string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.records[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &r, nil
+}
+
+// Put 保存验证码记录, 同时清理已过期的记录
+func (s *FileStore) Put(key string, r *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	purge(s.records)
+	s.records[key] = *r
+	return s.save()
+}
+
+// Delete 删除验证码记录
+func (s *FileStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.records[key]; !ok {
+		return nil
+	}
+	delete(s.records, key)
+	return s.save()
+}
+
+// save 写入临时文件后重命名, 保证文件内容完整
+func (s *FileStore) save() error {
+	data, err := json.Marshal(s.records)
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
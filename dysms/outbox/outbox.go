@@ -0,0 +1,354 @@
+// Package outbox Copyright 2016 The GiterLab Authors. All rights reserved.
+//
+// outbox 基于本地追加写日志的短信发件箱, 保证进程崩溃后待发送的短信不会丢失(至少发送一次)
+package outbox
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 默认参数
+const (
+	DefaultCompactThreshold = 1000           // 日志记录数超过该值且超过有效消息数的2倍时自动压缩
+	DefaultRetention        = 24 * time.Hour // 已提交或不再重试的消息自状态变更起的保留时间, 保留期内相同 OutID 的消息不会重复发送
+)
+
+// 发件箱错误
+var (
+	ErrOutIDRequired = errors.New("outbox message should have an OutID")
+	ErrNotFound      = errors.New("outbox message not found")
+	ErrClosed        = errors.New("outbox is closed")
+)
+
+// State 消息状态
+type State string
+
+// 消息状态取值
+const (
+	StatePending   State = "pending"   // 等待发送
+	StateSubmitted State = "submitted" // 已提交, 获得了 BizID
+	StateDead      State = "dead"      // 多次发送失败, 不再重试
+)
+
+// Message 发件箱中的短信
+type Message struct {
+	OutID         string    `json:"out_id"`                   // 外部流水扩展字段, 作为幂等键
+	PhoneNumbers  string    `json:"phone_numbers"`            // 短信接收号码, 多个号码使用,分割
+	SignName      string    `json:"sign_name"`                // 短信签名
+	TemplateCode  string    `json:"template_code"`            // 短信模板CODE
+	TemplateParam string    `json:"template_param,omitempty"` // 短信模板变量参数
+	CreatedAt     time.Time `json:"created_at"`               // 放入发件箱的时间
+
+	State       State     `json:"state"`                  // 消息状态
+	BizID       string    `json:"biz_id,omitempty"`       // 发送回执ID
+	SubmittedAt time.Time `json:"submitted_at,omitempty"` // 提交时间
+	Attempts    int       `json:"attempts,omitempty"`     // 发送失败次数
+	LastError   string    `json:"last_error,omitempty"`   // 最近一次发送失败的原因
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`   // 最近一次状态变更的时间
+}
+
+// 日志操作类型
+const (
+	opPut    = "put"
+	opSubmit = "submit"
+	opFail   = "fail"
+	opDead   = "dead"
+)
+
+// record 日志记录, 每行一条JSON
+type record struct {
+	Op      string    `json:"op"`
+	Message *Message  `json:"message,omitempty"`
+	OutID   string    `json:"out_id,omitempty"`
+	BizID   string    `json:"biz_id,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// Outbox 短信发件箱, 所有变更都以追加写的方式记录到日志文件并fsync, 可安全地并发使用
+type Outbox struct {
+	CompactThreshold int           // 自动压缩的日志记录数阈值, 为0时使用 DefaultCompactThreshold
+	Retention        time.Duration // 已提交或不再重试的消息自状态变更起的保留时间, 为0时使用 DefaultRetention
+
+	mu       sync.Mutex
+	path     string
+	file     *os.File
+	messages map[string]*Message
+	records  int
+	notify   chan struct{}
+}
+
+// Open 打开或创建发件箱日志文件, 并重放日志恢复发件箱状态
+func Open(path string) (*Outbox, error) {
+	o := &Outbox{
+		path:     path,
+		messages: make(map[string]*Message),
+		notify:   make(chan struct{}, 1),
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := o.replay(f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	o.file = f
+	return o, nil
+}
+
+// replay 重放日志, 进程崩溃时最后一条记录可能不完整, 将被截断
+func (o *Outbox) replay(f *os.File) error {
+	var offset int64
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			break
+		}
+		var rec record
+		if json.Unmarshal(line, &rec) != nil {
+			break
+		}
+		o.apply(&rec)
+		o.records++
+		offset += int64(len(line))
+	}
+	if err := f.Truncate(offset); err != nil {
+		return err
+	}
+	_, err := f.Seek(offset, 0)
+	return err
+}
+
+// apply 将日志记录应用到内存状态
+func (o *Outbox) apply(rec *record) {
+	if rec.Op == opPut {
+		if rec.Message != nil {
+			m := *rec.Message
+			o.messages[m.OutID] = &m
+		}
+		return
+	}
+	m, ok := o.messages[rec.OutID]
+	if !ok {
+		return
+	}
+	m.UpdatedAt = rec.Time
+	switch rec.Op {
+	case opSubmit:
+		m.State = StateSubmitted
+		m.BizID = rec.BizID
+		m.SubmittedAt = rec.Time
+	case opFail:
+		m.Attempts++
+		m.LastError = rec.Error
+	case opDead:
+		m.State = StateDead
+		m.LastError = rec.Error
+	}
+}
+
+// write 追加写一条日志记录并fsync, 然后应用到内存状态
+// 记录写入后自动压缩失败不影响本次写入, 日志仍然完整, 下次写入时重试压缩
+func (o *Outbox) write(rec *record) error {
+	if o.file == nil {
+		return ErrClosed
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := o.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if err := o.file.Sync(); err != nil {
+		return err
+	}
+	o.apply(rec)
+	o.records++
+	o.maybeCompact()
+	return nil
+}
+
+// Put 将短信放入发件箱, 写入日志后才返回
+// 已存在相同 OutID 的消息时不做任何操作, 因此重复提交是安全的
+func (o *Outbox) Put(m Message) error {
+	if m.OutID == "" {
+		return ErrOutIDRequired
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, ok := o.messages[m.OutID]; ok {
+		return nil
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	m.State = StatePending
+	m.BizID, m.SubmittedAt, m.Attempts, m.LastError = "", time.Time{}, 0, ""
+	if err := o.write(&record{Op: opPut, Message: &m, Time: m.CreatedAt}); err != nil {
+		return err
+	}
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// mark 记录消息状态变更
+func (o *Outbox) mark(rec *record) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if _, ok := o.messages[rec.OutID]; !ok {
+		return ErrNotFound
+	}
+	rec.Time = time.Now()
+	return o.write(rec)
+}
+
+// MarkSubmitted 标记消息已提交
+func (o *Outbox) MarkSubmitted(outID, bizID string) error {
+	return o.mark(&record{Op: opSubmit, OutID: outID, BizID: bizID})
+}
+
+// MarkFailed 记录一次发送失败, 消息仍等待重新发送
+func (o *Outbox) MarkFailed(outID string, err error) error {
+	return o.mark(&record{Op: opFail, OutID: outID, Error: err.Error()})
+}
+
+// MarkDead 标记消息不再重试
+func (o *Outbox) MarkDead(outID string, err error) error {
+	return o.mark(&record{Op: opDead, OutID: outID, Error: err.Error()})
+}
+
+// Get 获取消息
+func (o *Outbox) Get(outID string) (Message, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	m, ok := o.messages[outID]
+	if !ok {
+		return Message{}, false
+	}
+	return *m, true
+}
+
+// Pending 等待发送的消息, 按放入发件箱的时间排序
+func (o *Outbox) Pending() []Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var pending []Message
+	for _, m := range o.messages {
+		if m.State == StatePending {
+			pending = append(pending, *m)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+	return pending
+}
+
+// maybeCompact 日志记录过多时自动压缩
+func (o *Outbox) maybeCompact() error {
+	threshold := o.CompactThreshold
+	if threshold <= 0 {
+		threshold = DefaultCompactThreshold
+	}
+	if o.records < threshold || o.records < 2*len(o.messages) {
+		return nil
+	}
+	return o.compact()
+}
+
+// Compact 压缩日志, 每条消息只保留一条记录, 并删除状态变更后超过保留时间的已提交或不再重试的消息
+// 新日志写入并替换旧日志后才更新内存状态, 压缩失败时内存状态与旧日志保持一致
+func (o *Outbox) Compact() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.file == nil {
+		return ErrClosed
+	}
+	return o.compact()
+}
+
+func (o *Outbox) compact() error {
+	retention := o.Retention
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	messages := make(map[string]*Message, len(o.messages))
+	for id, m := range o.messages {
+		if m.State == StatePending || time.Since(m.UpdatedAt) <= retention {
+			messages[id] = m
+		}
+	}
+
+	tmp := o.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, m := range messages {
+		line, err := json.Marshal(&record{Op: opPut, Message: m, Time: m.CreatedAt})
+		if err == nil {
+			w.Write(line)
+			err = w.WriteByte('\n')
+		}
+		if err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, o.path); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	syncDir(filepath.Dir(o.path))
+	o.file.Close()
+	o.file = f
+	o.messages = messages
+	o.records = len(messages)
+	return nil
+}
+
+// syncDir fsync目录, 保证rename持久化
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
+
+// Close 关闭发件箱
+func (o *Outbox) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.file == nil {
+		return nil
+	}
+	err := o.file.Close()
+	o.file = nil
+	return err
+}
@@ -0,0 +1,132 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_Outbox(t *testing.T) {
+	dir, err := ioutil.TempDir("", "outbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "outbox.log")
+
+	o, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o.Put(Message{OutID: "1", PhoneNumbers: "13800000001", SignName: "sign", TemplateCode: "SMS_1"})
+	o.Put(Message{OutID: "2", PhoneNumbers: "13800000002", SignName: "sign", TemplateCode: "SMS_1"})
+	o.Put(Message{OutID: "1", PhoneNumbers: "13900000000"})
+	if err := o.Put(Message{}); err != ErrOutIDRequired {
+		t.Error("Put without OutID should fail", err)
+	}
+	o.Close()
+
+	// 模拟写入一半时进程崩溃
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	f.WriteString(`{"op":"submit","out_id":"1"`)
+	f.Close()
+
+	o, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending := o.Pending()
+	if len(pending) != 2 || pending[0].OutID != "1" || pending[0].PhoneNumbers != "13800000001" {
+		t.Fatal("replay failed", pending)
+	}
+
+	w := NewWorker(o)
+	w.Send = func(m Message) (string, error) {
+		if m.OutID == "2" {
+			return "", errors.New("send failed")
+		}
+		return "biz-" + m.OutID, nil
+	}
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := o.Get("1"); m.State != StateSubmitted || m.BizID != "biz-1" {
+		t.Error("MarkSubmitted failed", m)
+	}
+	if m, _ := o.Get("2"); m.State != StatePending || m.Attempts != 1 || m.LastError != "send failed" {
+		t.Error("MarkFailed failed", m)
+	}
+
+	if err := o.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	o.Put(Message{OutID: "3", PhoneNumbers: "13800000003"})
+	o.Close()
+	data, _ := ioutil.ReadFile(path)
+	if n := bytes.Count(data, []byte("\n")); n != 3 {
+		t.Error("Compact failed", n)
+	}
+
+	o, err = Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	if m, _ := o.Get("1"); m.State != StateSubmitted || m.BizID != "biz-1" {
+		t.Error("replay after compaction failed", m)
+	}
+	if m, _ := o.Get("2"); m.Attempts != 1 {
+		t.Error("replay after compaction failed", m)
+	}
+	if pending := o.Pending(); len(pending) != 2 {
+		t.Error("replay after compaction failed", pending)
+	}
+}
+
+func Test_OutboxCompact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "outbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "outbox.log")
+
+	o, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer o.Close()
+	o.Retention = time.Hour
+
+	// 等待发送超过保留时间的消息, 提交后仍按提交时间保留
+	o.Put(Message{OutID: "1", PhoneNumbers: "13800000001", CreatedAt: time.Now().Add(-48 * time.Hour)})
+	o.MarkSubmitted("1", "biz-1")
+	if err := o.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := o.Get("1"); !ok || m.State != StateSubmitted {
+		t.Error("Compact should keep recently submitted message", m)
+	}
+
+	// 压缩失败时不修改内存状态, 也不影响写入
+	os.Mkdir(path+".tmp", 0700)
+	o.Retention = time.Nanosecond
+	if err := o.Compact(); err == nil {
+		t.Error("Compact should fail")
+	}
+	if _, ok := o.Get("1"); !ok {
+		t.Error("failed Compact should keep messages")
+	}
+	o.CompactThreshold = 1
+	if err := o.Put(Message{OutID: "2", PhoneNumbers: "13800000002"}); err != nil {
+		t.Error("Put should not fail when compaction fails", err)
+	}
+	if err := o.MarkSubmitted("2", "biz-2"); err != nil {
+		t.Error("MarkSubmitted should not fail when compaction fails", err)
+	}
+}
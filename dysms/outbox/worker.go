@@ -0,0 +1,96 @@
+// Package outbox Copyright 2016 The GiterLab Authors. All rights reserved.
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+)
+
+// DefaultInterval 默认的发件箱扫描间隔
+const DefaultInterval = 5 * time.Second
+
+// Worker 发送发件箱中等待发送的短信, 获得 BizID 后标记为已提交
+type Worker struct {
+	Outbox      *Outbox
+	Send        func(m Message) (bizID string, err error) // 发送函数, 为nil时使用 dysms.SendSms 并以 OutID 作为外部流水扩展字段
+	Interval    time.Duration                             // 扫描间隔, 为0时使用 DefaultInterval; 有新消息放入时立即发送
+	MaxAttempts int                                       // 最大发送失败次数, 超过后标记为 StateDead, 为0时不限制
+	OnError     func(m Message, err error)                // 发送失败时的通知, 可用于记录日志
+}
+
+// NewWorker 创建一个发件箱发送者
+func NewWorker(o *Outbox) *Worker {
+	return &Worker{Outbox: o}
+}
+
+// send 使用 dysms.SendSms 发送短信
+func send(m Message) (string, error) {
+	resp, err := dysms.SendSms(m.OutID, m.PhoneNumbers, m.SignName, m.TemplateCode, m.TemplateParam).DoActionWithException()
+	if err != nil {
+		return "", err
+	}
+	if resp.GetCode() != "OK" {
+		return "", &resp.ErrorMessage
+	}
+	if resp.GetBizID() == "" {
+		return "", fmt.Errorf("SendSms returned no BizId for %s", m.OutID)
+	}
+	return resp.GetBizID(), nil
+}
+
+// Flush 依次发送所有等待发送的短信, ctx 结束时返回 ctx.Err()
+// 发送失败的短信会留在发件箱中等待下一次发送, 返回值只包含发件箱本身的错误
+func (w *Worker) Flush(ctx context.Context) error {
+	sendFunc := w.Send
+	if sendFunc == nil {
+		sendFunc = send
+	}
+	for _, m := range w.Outbox.Pending() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		bizID, err := sendFunc(m)
+		if err == nil {
+			if err := w.Outbox.MarkSubmitted(m.OutID, bizID); err != nil {
+				return err
+			}
+			continue
+		}
+		if w.OnError != nil {
+			w.OnError(m, err)
+		}
+		if w.MaxAttempts > 0 && m.Attempts+1 >= w.MaxAttempts {
+			err = w.Outbox.MarkDead(m.OutID, err)
+		} else {
+			err = w.Outbox.MarkFailed(m.OutID, err)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run 启动后立即重放发件箱中的短信, 之后定期或在有新消息时发送, 直到 ctx 结束
+func (w *Worker) Run(ctx context.Context) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := w.Flush(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-w.Outbox.notify:
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"strings"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/phone"
+)
+
+// AutoNormalizePhoneNumbers 发送短信前是否自动规范化、校验并去重短信接收号码
+// 开启后, 存在未通过校验的号码时不发送短信, 返回 phone.ListError 列出每个号码的原因
+var AutoNormalizePhoneNumbers = false
+
+// FormatPhoneNumber 将规范化后的号码转换为 SendSms 接口的格式
+// 国内号码为11位手机号, 国际号码为00+国际区号+号码
+func FormatPhoneNumber(n phone.Number) string {
+	if n.International {
+		return "00" + n.National
+	}
+	return n.National
+}
+
+// NormalizePhoneNumbers 规范化、校验并去重短信接收号码, 仅保留有效号码
+// 存在未通过校验的号码时返回 phone.ListError 且不修改号码, 国际号码转换为00+国际区号+号码的格式
+func (s *SendSmsRequest) NormalizePhoneNumbers() error {
+	numbers, rejected := phone.ParseList(strings.Split(s.GetPhoneNumbers(), ","))
+	if len(rejected) > 0 {
+		return rejected
+	}
+	list := make([]string, len(numbers))
+	for i, n := range numbers {
+		list[i] = FormatPhoneNumber(n)
+	}
+	s.SetPhoneNumbers(strings.Join(list, ","))
+	return nil
+}
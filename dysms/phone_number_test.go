@@ -0,0 +1,25 @@
+package dysms
+
+import (
+	"testing"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/phone"
+)
+
+func Test_NormalizePhoneNumbers(t *testing.T) {
+	r := SendSms("1", "+86 138-0013-8000,13800138000,+65 9123 4567", "sign", "SMS_1", "")
+	if err := r.NormalizePhoneNumbers(); err != nil || r.GetPhoneNumbers() != "13800138000,006591234567" {
+		t.Error("NormalizePhoneNumbers failed", r.GetPhoneNumbers(), err)
+	}
+
+	// 存在无效号码时不修改号码
+	r = SendSms("1", "+86 138-0013-8000,12345", "sign", "SMS_1", "")
+	err := r.NormalizePhoneNumbers()
+	if r.GetPhoneNumbers() != "+86 138-0013-8000,12345" {
+		t.Error("NormalizePhoneNumbers should not modify phone numbers on error", r.GetPhoneNumbers())
+	}
+	rejected, ok := err.(phone.ListError)
+	if !ok || len(rejected) != 1 || rejected[0].Input != "12345" || rejected[0].Reason != phone.ErrInvalidLength {
+		t.Error("NormalizePhoneNumbers rejected failed", err)
+	}
+}
@@ -0,0 +1,27 @@
+// Package push Copyright 2016 The GiterLab Authors. All rights reserved.
+package push
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var errMethodNotAllowed = errors.New("method not allowed")
+
+// BatchError 一批推送消息中处理失败的消息
+type BatchError struct {
+	Total  int           // 本批次消息总数
+	Failed map[int]error // 处理失败的消息序号及错误
+}
+
+// Error 错误描述
+func (e *BatchError) Error() string {
+	msgs := make([]string, 0, len(e.Failed))
+	for i := 0; i < e.Total; i++ {
+		if err, ok := e.Failed[i]; ok {
+			msgs = append(msgs, "#"+strconv.Itoa(i)+": "+err.Error())
+		}
+	}
+	return strconv.Itoa(len(e.Failed)) + "/" + strconv.Itoa(e.Total) + " failed: " + strings.Join(msgs, "; ")
+}
@@ -0,0 +1,57 @@
+// Package push Copyright 2016 The GiterLab Authors. All rights reserved.
+//
+// push 实现短信服务HTTP批量推送模式的接收端, 包括短信发送状态报告(SmsReport)和上行短信(SmsUp)
+package push
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+)
+
+// DefaultMaxBodySize 默认的推送请求体大小上限
+const DefaultMaxBodySize = 1 << 20
+
+// parseTime 解析推送消息中的时间, 格式为yyyy-MM-dd HH:mm:ss
+func parseTime(s string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 15:04:05", s, dysms.CSTZone)
+}
+
+// Response 推送接收端的应答
+type Response struct {
+	Code int    `json:"code"` // 0表示接收成功, 其他值表示接收失败, 短信服务将重新推送
+	Msg  string `json:"msg"`  // 应答描述
+}
+
+// writeResponse 以短信服务要求的格式应答推送请求
+func writeResponse(w http.ResponseWriter, httpCode, code int, msg string) {
+	body, _ := json.Marshal(Response{Code: code, Msg: msg})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(httpCode)
+	w.Write(body)
+}
+
+// writeSucceed 应答接收成功
+func writeSucceed(w http.ResponseWriter) {
+	writeResponse(w, http.StatusOK, 0, "成功")
+}
+
+// readBatch 读取并解析推送请求体中的JSON数组, 返回应答用的HTTP状态码
+func readBatch(w http.ResponseWriter, r *http.Request, maxBodySize int64, v interface{}) (int, error) {
+	if r.Method != http.MethodPost {
+		return http.StatusMethodNotAllowed, errMethodNotAllowed
+	}
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(v)
+	if err != nil {
+		if _, ok := err.(*http.MaxBytesError); ok {
+			return http.StatusRequestEntityTooLarge, err
+		}
+		return http.StatusBadRequest, err
+	}
+	return http.StatusOK, nil
+}
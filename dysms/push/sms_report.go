@@ -0,0 +1,81 @@
+// Package push Copyright 2016 The GiterLab Authors. All rights reserved.
+package push
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// SmsReport 短信发送状态报告
+type SmsReport struct {
+	PhoneNumber string      `json:"phone_number"` // 短信接收号码
+	SendTime    string      `json:"send_time"`    // 发送时间
+	ReportTime  string      `json:"report_time"`  // 状态报告时间
+	Success     bool        `json:"success"`      // 是否接收成功
+	ErrCode     string      `json:"err_code"`     // 状态报告编码
+	ErrMsg      string      `json:"err_msg"`      // 状态报告说明
+	SmsSize     json.Number `json:"sms_size"`     // 140字节算一条短信, 短信长度超过140字节时会拆分成多条短信发送
+	BizID       string      `json:"biz_id"`       // 发送回执ID
+	OutID       string      `json:"out_id"`       // 调用发送短信接口时传的外部流水扩展字段
+}
+
+// GetSendTime 解析发送时间
+func (s *SmsReport) GetSendTime() (time.Time, error) {
+	return parseTime(s.SendTime)
+}
+
+// GetReportTime 解析状态报告时间
+func (s *SmsReport) GetReportTime() (time.Time, error) {
+	return parseTime(s.ReportTime)
+}
+
+// GetSmsSize 获取短信计费条数
+func (s *SmsReport) GetSmsSize() int {
+	n, _ := s.SmsSize.Int64()
+	return int(n)
+}
+
+// SmsReportHandler 短信发送状态报告推送接收端, 实现 http.Handler
+// 一批状态报告中的每一条都会调用一次 Callback, 任意一条处理失败时应答失败, 短信服务会重新推送整批状态报告,
+// 因此 Callback 需要根据 BizID 和 PhoneNumber 保证幂等
+type SmsReportHandler struct {
+	Callback    func(report *SmsReport) error // 处理一条状态报告
+	MaxBodySize int64                         // 推送请求体大小上限, 为0时使用 DefaultMaxBodySize
+}
+
+// NewSmsReportHandler 创建一个短信发送状态报告推送接收端
+func NewSmsReportHandler(callback func(report *SmsReport) error) *SmsReportHandler {
+	return &SmsReportHandler{Callback: callback, MaxBodySize: DefaultMaxBodySize}
+}
+
+// ServeHTTP 接收并处理一批状态报告
+func (h *SmsReportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var reports []SmsReport
+	if httpCode, err := readBatch(w, r, h.MaxBodySize, &reports); err != nil {
+		writeResponse(w, httpCode, 1, err.Error())
+		return
+	}
+	if err := h.handle(reports); err != nil {
+		writeResponse(w, http.StatusOK, 1, err.Error())
+		return
+	}
+	writeSucceed(w)
+}
+
+// handle 依次处理一批状态报告, 单条失败不影响其他状态报告的处理
+func (h *SmsReportHandler) handle(reports []SmsReport) error {
+	if h.Callback == nil {
+		return nil
+	}
+	e := &BatchError{Total: len(reports), Failed: make(map[int]error)}
+	for i := range reports {
+		if err := h.Callback(&reports[i]); err != nil {
+			e.Failed[i] = err
+		}
+	}
+	if len(e.Failed) > 0 {
+		return e
+	}
+	return nil
+}
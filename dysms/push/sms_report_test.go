@@ -0,0 +1,70 @@
+package push
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testSmsReports = `[{"phone_number":"1381111****","send_time":"2017-01-01 00:00:00","report_time":"2017-01-01 00:00:05","success":true,"err_code":"DELIVERED","err_msg":"用户接收成功","sms_size":"1","biz_id":"12345","out_id":"67890"},
+{"phone_number":"1382222****","send_time":"2017-01-01 00:00:00","report_time":"2017-01-01 00:00:05","success":false,"err_code":"MK:0001","err_msg":"","sms_size":"2","biz_id":"12345","out_id":"67890"}]`
+
+func doPush(h http.Handler, body string) (*httptest.ResponseRecorder, Response) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/sms/report", strings.NewReader(body)))
+	var resp Response
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	return w, resp
+}
+
+func Test_SmsReportHandler(t *testing.T) {
+	var reports []*SmsReport
+	h := NewSmsReportHandler(func(report *SmsReport) error {
+		reports = append(reports, report)
+		return nil
+	})
+	w, resp := doPush(h, testSmsReports)
+	if w.Code != http.StatusOK || resp.Code != 0 || resp.Msg != "成功" {
+		t.Fatal("SmsReportHandler response failed", w.Body.String())
+	}
+	if len(reports) != 2 || !reports[0].Success || reports[1].ErrCode != "MK:0001" || reports[1].GetSmsSize() != 2 {
+		t.Error("SmsReportHandler parse failed")
+	}
+	if ts, err := reports[0].GetReportTime(); err != nil || ts.UTC().Format("2006-01-02 15:04:05") != "2016-12-31 16:00:05" {
+		t.Error("GetReportTime failed", ts, err)
+	}
+}
+
+func Test_SmsReportHandlerPartialFailure(t *testing.T) {
+	calls := 0
+	h := NewSmsReportHandler(func(report *SmsReport) error {
+		calls++
+		if report.PhoneNumber == "1381111****" {
+			return errors.New("db error")
+		}
+		return nil
+	})
+	_, resp := doPush(h, testSmsReports)
+	if resp.Code == 0 || calls != 2 {
+		t.Error("SmsReportHandler partial failure failed", resp, calls)
+	}
+}
+
+func Test_SmsReportHandlerBadRequest(t *testing.T) {
+	h := NewSmsReportHandler(nil)
+	h.MaxBodySize = 16
+	if w, resp := doPush(h, testSmsReports); w.Code != http.StatusRequestEntityTooLarge || resp.Code == 0 {
+		t.Error("SmsReportHandler body limit failed", w.Code)
+	}
+	if w, _ := doPush(h, `{`); w.Code != http.StatusBadRequest {
+		t.Error("SmsReportHandler bad body failed", w.Code)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sms/report", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Error("SmsReportHandler method failed", w.Code)
+	}
+}
@@ -0,0 +1,117 @@
+// Package push Copyright 2016 The GiterLab Authors. All rights reserved.
+package push
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultDedupeWindow 默认的上行短信去重时间窗口
+const DefaultDedupeWindow = 24 * time.Hour
+
+// SmsUp 上行短信
+type SmsUp struct {
+	PhoneNumber string `json:"phone_number"` // 发送上行短信的手机号
+	SendTime    string `json:"send_time"`    // 发送时间
+	Content     string `json:"content"`      // 上行短信内容
+	SignName    string `json:"sign_name"`    // 签名信息
+	DestCode    string `json:"dest_code"`    // 上行短信扩展号码
+	SequenceID  int64  `json:"sequence_id"`  // 序列号
+}
+
+// GetSendTime 解析发送时间
+func (s *SmsUp) GetSendTime() (time.Time, error) {
+	return parseTime(s.SendTime)
+}
+
+// SmsUpHandler 上行短信推送接收端, 实现 http.Handler
+// 短信服务可能重复推送同一条上行短信, 在 DedupeWindow 时间窗口内已处理成功或正在处理的 SequenceID 不会再次调用 Callback
+type SmsUpHandler struct {
+	Callback     func(up *SmsUp) error // 处理一条上行短信
+	MaxBodySize  int64                 // 推送请求体大小上限, 为0时使用 DefaultMaxBodySize
+	DedupeWindow time.Duration         // 去重时间窗口, 为0时使用 DefaultDedupeWindow
+
+	mu        sync.Mutex
+	seen      map[int64]time.Time
+	lastSweep time.Time
+}
+
+// NewSmsUpHandler 创建一个上行短信推送接收端
+func NewSmsUpHandler(callback func(up *SmsUp) error) *SmsUpHandler {
+	return &SmsUpHandler{Callback: callback, MaxBodySize: DefaultMaxBodySize, DedupeWindow: DefaultDedupeWindow}
+}
+
+// ServeHTTP 接收并处理一批上行短信
+func (h *SmsUpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var ups []SmsUp
+	if httpCode, err := readBatch(w, r, h.MaxBodySize, &ups); err != nil {
+		writeResponse(w, httpCode, 1, err.Error())
+		return
+	}
+	if err := h.handle(ups); err != nil {
+		writeResponse(w, http.StatusOK, 1, err.Error())
+		return
+	}
+	writeSucceed(w)
+}
+
+// handle 依次处理一批上行短信, 单条失败不影响其他上行短信的处理
+func (h *SmsUpHandler) handle(ups []SmsUp) error {
+	if h.Callback == nil {
+		return nil
+	}
+	e := &BatchError{Total: len(ups), Failed: make(map[int]error)}
+	for i := range ups {
+		if !h.reserve(ups[i].SequenceID) {
+			continue
+		}
+		if err := h.Callback(&ups[i]); err != nil {
+			h.release(ups[i].SequenceID)
+			e.Failed[i] = err
+		}
+	}
+	if len(e.Failed) > 0 {
+		return e
+	}
+	return nil
+}
+
+// window 去重时间窗口
+func (h *SmsUpHandler) window() time.Duration {
+	if h.DedupeWindow <= 0 {
+		return DefaultDedupeWindow
+	}
+	return h.DedupeWindow
+}
+
+// reserve 在调用 Callback 前占用序列号, 时间窗口内已处理或正在处理时返回false
+// 并发的重复推送只有一个能调用 Callback, 并定期清理过期的记录
+func (h *SmsUpHandler) reserve(sequenceID int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	if t, ok := h.seen[sequenceID]; ok && now.Sub(t) < h.window() {
+		return false
+	}
+	if h.seen == nil {
+		h.seen = make(map[int64]time.Time)
+	}
+	h.seen[sequenceID] = now
+	if now.Sub(h.lastSweep) > h.window() {
+		for id, t := range h.seen {
+			if now.Sub(t) >= h.window() {
+				delete(h.seen, id)
+			}
+		}
+		h.lastSweep = now
+	}
+	return true
+}
+
+// release Callback 处理失败时释放序列号, 重新推送时可以再次处理
+func (h *SmsUpHandler) release(sequenceID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.seen, sequenceID)
+}
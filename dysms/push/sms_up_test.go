@@ -0,0 +1,80 @@
+package push
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testSmsUps = `[{"phone_number":"1381111****","send_time":"2017-01-01 00:00:00","content":"TD","sign_name":"阿里云","dest_code":"1234","sequence_id":1234567890},
+{"phone_number":"1381111****","send_time":"2017-01-01 00:00:00","content":"TD","sign_name":"阿里云","dest_code":"1234","sequence_id":1234567890}]`
+
+func Test_SmsUpHandler(t *testing.T) {
+	var ups []*SmsUp
+	up := NewSmsUpHandler(func(up *SmsUp) error {
+		ups = append(ups, up)
+		return nil
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/sms/report", NewSmsReportHandler(nil))
+	mux.Handle("/sms/up", up)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/sms/up", strings.NewReader(testSmsUps)))
+	if w.Code != http.StatusOK || w.Body.String() != `{"code":0,"msg":"成功"}` {
+		t.Fatal("SmsUpHandler response failed", w.Body.String())
+	}
+	if len(ups) != 1 || ups[0].Content != "TD" || ups[0].SequenceID != 1234567890 || ups[0].DestCode != "1234" {
+		t.Error("SmsUpHandler dedupe failed", len(ups))
+	}
+
+	// 重复推送
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/sms/up", strings.NewReader(testSmsUps)))
+	if w.Code != http.StatusOK || len(ups) != 1 {
+		t.Error("SmsUpHandler redelivery dedupe failed", len(ups))
+	}
+}
+
+func Test_SmsUpHandlerConcurrentRedelivery(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	fail := true
+	release := make(chan struct{})
+	up := NewSmsUpHandler(func(up *SmsUp) error {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		<-release
+		if fail {
+			return errors.New("callback failed")
+		}
+		return nil
+	})
+
+	// 并发的重复推送只调用一次 Callback
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			up.handle([]SmsUp{{SequenceID: 1}})
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls != 1 {
+		t.Error("SmsUpHandler concurrent redelivery failed", calls)
+	}
+
+	// Callback 失败后重新推送可以再次处理
+	fail = false
+	if err := up.handle([]SmsUp{{SequenceID: 1}}); err != nil || calls != 2 {
+		t.Error("SmsUpHandler release failed", calls, err)
+	}
+}
@@ -0,0 +1,88 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// CardSmsTemplateData 卡片短信模板查询结果
+type CardSmsTemplateData struct {
+	Templates []map[string]interface{} `json:"Templates"` // 卡片短信模板内容
+}
+
+// QueryCardSmsTemplateResponse 查询卡片短信模板接口服务器响应
+type QueryCardSmsTemplateResponse struct {
+	ErrorMessage
+	Data *CardSmsTemplateData `json:"Data,omitempty"` // 模板查询结果
+}
+
+// GetTemplates 获取卡片短信模板内容
+func (q *QueryCardSmsTemplateResponse) GetTemplates() []map[string]interface{} {
+	if q != nil && q.Data != nil {
+		return q.Data.Templates
+	}
+	return nil
+}
+
+// String 序列化成JSON字符串
+func (q QueryCardSmsTemplateResponse) String() string {
+	body, err := json.Marshal(q)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// QueryCardSmsTemplateRequest 查询卡片短信模板接口请求
+type QueryCardSmsTemplateRequest struct {
+	Request *Request
+}
+
+// SetTemplateCode 设置卡片短信模板ID 必须
+func (q *QueryCardSmsTemplateRequest) SetTemplateCode(templateCode string) {
+	if q != nil && q.Request != nil {
+		q.Request.Put("TemplateCode", templateCode)
+	}
+}
+
+// GetTemplateCode 获取卡片短信模板ID
+func (q *QueryCardSmsTemplateRequest) GetTemplateCode() string {
+	if q != nil && q.Request != nil {
+		return q.Request.Get("TemplateCode")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (q *QueryCardSmsTemplateRequest) DoActionWithException() (resp *QueryCardSmsTemplateResponse, err error) {
+	if q != nil && q.Request != nil {
+		resp := &QueryCardSmsTemplateResponse{}
+		body, httpCode, err := q.Request.Do("QueryCardSmsTemplate")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("QueryCardSmsTemplateRequest is nil")
+}
+
+// QueryCardSmsTemplate 查询卡片短信模板接口
+// templateCode 必填 - 卡片短信模板ID
+func QueryCardSmsTemplate(templateCode string) *QueryCardSmsTemplateRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "QueryCardSmsTemplate")
+
+	r := &QueryCardSmsTemplateRequest{Request: req}
+	r.SetTemplateCode(templateCode) // 必填 - 卡片短信模板ID
+	return r
+}
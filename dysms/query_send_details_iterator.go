@@ -0,0 +1,147 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// QuerySendDetailsMaxPageSize QuerySendDetails 接口的页大小上限
+const QuerySendDetailsMaxPageSize = 50
+
+// DefaultQuerySendDetailsInterval 逐页查询时两次请求之间的默认最小间隔
+var DefaultQuerySendDetailsInterval = 100 * time.Millisecond
+
+// sendDetailKey 短信发送记录的去重键, 不含回执相关的字段
+type sendDetailKey struct {
+	PhoneNum     string
+	TemplateCode string
+	Content      string
+	SendDate     string
+	OutID        string
+}
+
+// SendDetailsIterator 按需逐页获取某个号码某天的全部短信发送记录
+// 记录仍在写入时, 新记录会使已读取的记录移动到后面的页, 迭代器会跳过已返回过的记录
+type SendDetailsIterator struct {
+	ctx      context.Context
+	request  *QuerySendDetailsRequest
+	pageSize int
+	interval time.Duration
+
+	page      int
+	buf       []SmsSendDetailDTO
+	current   SmsSendDetailDTO
+	seen      map[sendDetailKey]bool
+	lastFetch time.Time
+	done      bool
+	err       error
+}
+
+// NewSendDetailsIterator 创建一个短信发送记录迭代器, 使用 request 中的号码、日期和流水号查询
+// request 中的页码会被迭代器覆盖, 页大小未设置时使用 QuerySendDetailsMaxPageSize
+func NewSendDetailsIterator(ctx context.Context, request *QuerySendDetailsRequest) *SendDetailsIterator {
+	pageSize := request.GetPageSizeInt()
+	if pageSize <= 0 || pageSize > QuerySendDetailsMaxPageSize {
+		pageSize = QuerySendDetailsMaxPageSize
+	}
+	return &SendDetailsIterator{
+		ctx:      ctx,
+		request:  request,
+		pageSize: pageSize,
+		interval: DefaultQuerySendDetailsInterval,
+		seen:     make(map[sendDetailKey]bool),
+	}
+}
+
+// SetInterval 设置两次请求之间的最小间隔, 用于限制查询频率
+func (it *SendDetailsIterator) SetInterval(interval time.Duration) {
+	it.interval = interval
+}
+
+// Next 移动到下一条记录, 没有更多记录或出错时返回false, 出错原因通过 Err 获取
+func (it *SendDetailsIterator) Next() bool {
+	for len(it.buf) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.err = it.fetch()
+	}
+	it.current, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Detail 当前记录
+func (it *SendDetailsIterator) Detail() SmsSendDetailDTO {
+	return it.current
+}
+
+// Err 迭代过程中发生的错误
+func (it *SendDetailsIterator) Err() error {
+	return it.err
+}
+
+// All 获取全部剩余的记录
+func (it *SendDetailsIterator) All() ([]SmsSendDetailDTO, error) {
+	var all []SmsSendDetailDTO
+	for it.Next() {
+		all = append(all, it.Detail())
+	}
+	return all, it.Err()
+}
+
+// wait 等待到允许发起下一次请求
+func (it *SendDetailsIterator) wait() error {
+	if err := it.ctx.Err(); err != nil {
+		return err
+	}
+	d := it.interval - time.Since(it.lastFetch)
+	if it.lastFetch.IsZero() || d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-it.ctx.Done():
+		return it.ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// fetch 获取下一页记录
+func (it *SendDetailsIterator) fetch() error {
+	if it.request == nil || it.request.Request == nil {
+		return errors.New("QuerySendDetailsRequest is nil")
+	}
+	if err := it.wait(); err != nil {
+		return err
+	}
+	it.page++
+	it.request.SetCurrentPageInt(it.page)
+	it.request.SetPageSizeInt(it.pageSize)
+	it.lastFetch = time.Now()
+	resp, err := it.request.DoActionWithException()
+	if err != nil {
+		return err
+	}
+	if resp.GetCode() != "OK" {
+		return &resp.ErrorMessage
+	}
+	var details []SmsSendDetailDTO
+	if dtos := resp.GetSmsSendDetailDTOs(); dtos != nil {
+		details = dtos.SmsSendDetailDTO
+	}
+	for _, d := range details {
+		key := sendDetailKey{d.PhoneNum, d.TemplateCode, d.Content, d.SendDate, d.OutID}
+		if !it.seen[key] {
+			it.seen[key] = true
+			it.buf = append(it.buf, d)
+		}
+	}
+	if len(details) < it.pageSize || it.page*it.pageSize >= resp.GetTotalCount() {
+		it.done = true
+	}
+	return nil
+}
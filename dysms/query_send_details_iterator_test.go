@@ -0,0 +1,51 @@
+package dysms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_SendDetailsIterator(t *testing.T) {
+	// 每查询一页都有一条新记录插入到最前面
+	total := 3
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("CurrentPage"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("PageSize"))
+		var details []string
+		for i := (page - 1) * size; i < page*size && i < total; i++ {
+			details = append(details, fmt.Sprintf(`{"PhoneNum":"13800000000","SendStatus":3,"OutId":"%d"}`, total-i))
+		}
+		fmt.Fprintf(w, `{"Code":"OK","TotalCount":%d,"SmsSendDetailDTOs":{"SmsSendDetailDTO":[%s]}}`, total, strings.Join(details, ","))
+		total++
+	}))
+	defer ts.Close()
+	defer setTestEndPoint(ts.URL + "/")()
+
+	it := NewSendDetailsIterator(context.Background(), QuerySendDetails("", "13800000000", "2", "1", "20170525"))
+	it.SetInterval(0)
+	details, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, d := range details {
+		ids = append(ids, d.OutID)
+	}
+	if strings.Join(ids, ",") != "3,2,1" {
+		t.Error("SendDetailsIterator failed", ids)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it = NewSendDetailsIterator(ctx, QuerySendDetails("", "13800000000", "", "1", "20170525"))
+	it.SetInterval(time.Second)
+	if it.Next() || it.Err() != context.Canceled {
+		t.Error("SendDetailsIterator cancel failed", it.Err())
+	}
+}
@@ -16,6 +16,7 @@ type SmsSendDetailDTO struct {
 	SendDate     string `json:"SendDate"`     // 发送时间
 	ReceiveDate  string `json:"ReceiveDate"`  // 接收时间
 	OutID        string `json:"OutId"`        // 外部流水扩展字段
+	BizID        string `json:"BizId"`        // 发送回执ID, 服务器未返回时为空
 }
 
 // SmsSendDetailDTOs 短信发送记录查询列表
@@ -0,0 +1,83 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// QueryShortURLResponse 查询短链状态接口服务器响应
+type QueryShortURLResponse struct {
+	ErrorMessage
+	Data *ShortURLData `json:"Data,omitempty"` // 短链信息, 包括审核状态和访问统计
+}
+
+// GetData 获取短链信息
+func (q *QueryShortURLResponse) GetData() *ShortURLData {
+	if q != nil && q.Data != nil {
+		return q.Data
+	}
+	return nil
+}
+
+// String 序列化成JSON字符串
+func (q QueryShortURLResponse) String() string {
+	body, err := json.Marshal(q)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// QueryShortURLRequest 查询短链状态接口请求
+type QueryShortURLRequest struct {
+	Request *Request
+}
+
+// SetShortURL 设置短链地址 必须
+func (q *QueryShortURLRequest) SetShortURL(shortURL string) {
+	if q != nil && q.Request != nil {
+		q.Request.Put("ShortUrl", shortURL)
+	}
+}
+
+// GetShortURL 获取短链地址
+func (q *QueryShortURLRequest) GetShortURL() string {
+	if q != nil && q.Request != nil {
+		return q.Request.Get("ShortUrl")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (q *QueryShortURLRequest) DoActionWithException() (resp *QueryShortURLResponse, err error) {
+	if q != nil && q.Request != nil {
+		resp := &QueryShortURLResponse{}
+		body, httpCode, err := q.Request.Do("QueryShortUrl")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("QueryShortURLRequest is nil")
+}
+
+// QueryShortURL 查询短链状态接口
+// shortURL 必填 - 短链地址
+func QueryShortURL(shortURL string) *QueryShortURLRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "QueryShortUrl")
+
+	r := &QueryShortURLRequest{Request: req}
+	r.SetShortURL(shortURL) // 必填 - 短链地址
+	return r
+}
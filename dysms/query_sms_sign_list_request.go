@@ -0,0 +1,151 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// SmsSignReason 签名审核未通过的原因
+type SmsSignReason struct {
+	RejectDate    string `json:"RejectDate"`    // 审批未通过的时间
+	RejectInfo    string `json:"RejectInfo"`    // 审批未通过的原因
+	RejectSubInfo string `json:"RejectSubInfo"` // 审批未通过的备注信息
+}
+
+// SmsSignDTO 短信签名信息
+type SmsSignDTO struct {
+	SignName     string         `json:"SignName"`     // 签名名称
+	AuditStatus  AuditStatus    `json:"AuditStatus"`  // 签名审核状态
+	CreateDate   string         `json:"CreateDate"`   // 短信签名的创建日期和时间
+	Reason       *SmsSignReason `json:"Reason"`       // 审核备注
+	BusinessType string         `json:"BusinessType"` // 签名场景类型
+	OrderID      string         `json:"OrderId"`      // 工单号
+}
+
+// QuerySmsSignListResponse 查询短信签名列表接口服务器响应
+type QuerySmsSignListResponse struct {
+	ErrorMessage
+	SmsSignList []SmsSignDTO `json:"SmsSignList,omitempty"` // 短信签名列表
+	TotalCount  *int         `json:"TotalCount,omitempty"`  // 短信签名总数
+	CurrentPage *int         `json:"CurrentPage,omitempty"` // 当前页码
+	PageSize    *int         `json:"PageSize,omitempty"`    // 每页显示的签名个数
+}
+
+// GetSmsSignList 获取短信签名列表
+func (q *QuerySmsSignListResponse) GetSmsSignList() []SmsSignDTO {
+	if q != nil {
+		return q.SmsSignList
+	}
+	return nil
+}
+
+// GetTotalCount 短信签名总数
+func (q *QuerySmsSignListResponse) GetTotalCount() int {
+	if q != nil && q.TotalCount != nil {
+		return *q.TotalCount
+	}
+	return 0
+}
+
+// GetCurrentPage 当前页码
+func (q *QuerySmsSignListResponse) GetCurrentPage() int {
+	if q != nil && q.CurrentPage != nil {
+		return *q.CurrentPage
+	}
+	return 0
+}
+
+// GetPageSize 每页显示的签名个数
+func (q *QuerySmsSignListResponse) GetPageSize() int {
+	if q != nil && q.PageSize != nil {
+		return *q.PageSize
+	}
+	return 0
+}
+
+// String 序列化成JSON字符串
+func (q QuerySmsSignListResponse) String() string {
+	body, err := json.Marshal(q)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// QuerySmsSignListRequest 查询短信签名列表接口请求
+type QuerySmsSignListRequest struct {
+	Request *Request
+}
+
+// SetPageIndex 设置当前页码, 默认取值为1
+func (q *QuerySmsSignListRequest) SetPageIndex(pageIndex int) {
+	if q != nil && q.Request != nil {
+		q.Request.Put("PageIndex", strconv.Itoa(pageIndex))
+	}
+}
+
+// GetPageIndex 获取当前页码
+func (q *QuerySmsSignListRequest) GetPageIndex() int {
+	if q != nil && q.Request != nil {
+		n, _ := strconv.Atoi(q.Request.Get("PageIndex"))
+		return n
+	}
+	return 0
+}
+
+// SetPageSize 设置每页显示的签名个数, 默认取值为10, 取值范围1~50
+func (q *QuerySmsSignListRequest) SetPageSize(pageSize int) {
+	if q != nil && q.Request != nil {
+		q.Request.Put("PageSize", strconv.Itoa(pageSize))
+	}
+}
+
+// GetPageSize 获取每页显示的签名个数
+func (q *QuerySmsSignListRequest) GetPageSize() int {
+	if q != nil && q.Request != nil {
+		n, _ := strconv.Atoi(q.Request.Get("PageSize"))
+		return n
+	}
+	return 0
+}
+
+// DoActionWithException 发起HTTP请求
+func (q *QuerySmsSignListRequest) DoActionWithException() (resp *QuerySmsSignListResponse, err error) {
+	if q != nil && q.Request != nil {
+		resp := &QuerySmsSignListResponse{}
+		body, httpCode, err := q.Request.Do("QuerySmsSignList")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("QuerySmsSignListRequest is nil")
+}
+
+// QuerySmsSignList 查询短信签名列表接口
+// pageIndex 可选 - 当前页码, 小于等于0时使用默认值
+// pageSize 可选 - 每页显示的签名个数, 小于等于0时使用默认值
+func QuerySmsSignList(pageIndex, pageSize int) *QuerySmsSignListRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "QuerySmsSignList")
+
+	r := &QuerySmsSignListRequest{Request: req}
+	if pageIndex > 0 {
+		r.SetPageIndex(pageIndex) // 可选 - 当前页码
+	}
+	if pageSize > 0 {
+		r.SetPageSize(pageSize) // 可选 - 每页显示的签名个数
+	}
+	return r
+}
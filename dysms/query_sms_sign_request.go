@@ -0,0 +1,115 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// QuerySmsSignResponse 查询短信签名申请状态接口服务器响应
+type QuerySmsSignResponse struct {
+	ErrorMessage
+	SignName   *string     `json:"SignName,omitempty"`   // 签名名称
+	SignStatus *SignStatus `json:"SignStatus,omitempty"` // 签名审核状态
+	Reason     *string     `json:"Reason,omitempty"`     // 审核备注, 审核失败时为失败原因
+	CreateDate *string     `json:"CreateDate,omitempty"` // 短信签名的创建日期和时间
+}
+
+// GetSignName 获取签名名称
+func (q *QuerySmsSignResponse) GetSignName() string {
+	if q != nil && q.SignName != nil {
+		return *q.SignName
+	}
+	return ""
+}
+
+// GetSignStatus 获取签名审核状态
+func (q *QuerySmsSignResponse) GetSignStatus() SignStatus {
+	if q != nil && q.SignStatus != nil {
+		return *q.SignStatus
+	}
+	return SignStatusAuditing
+}
+
+// GetReason 获取审核备注
+func (q *QuerySmsSignResponse) GetReason() string {
+	if q != nil && q.Reason != nil {
+		return *q.Reason
+	}
+	return ""
+}
+
+// GetCreateDate 获取短信签名的创建日期和时间
+func (q *QuerySmsSignResponse) GetCreateDate() string {
+	if q != nil && q.CreateDate != nil {
+		return *q.CreateDate
+	}
+	return ""
+}
+
+// Approved 签名是否已审核通过
+func (q *QuerySmsSignResponse) Approved() bool {
+	return q != nil && q.SignStatus != nil && *q.SignStatus == SignStatusApproved
+}
+
+// String 序列化成JSON字符串
+func (q QuerySmsSignResponse) String() string {
+	body, err := json.Marshal(q)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// QuerySmsSignRequest 查询短信签名申请状态接口请求
+type QuerySmsSignRequest struct {
+	Request *Request
+}
+
+// SetSignName 设置签名名称 必须
+func (q *QuerySmsSignRequest) SetSignName(signName string) {
+	if q != nil && q.Request != nil {
+		q.Request.Put("SignName", signName)
+	}
+}
+
+// GetSignName 获取签名名称
+func (q *QuerySmsSignRequest) GetSignName() string {
+	if q != nil && q.Request != nil {
+		return q.Request.Get("SignName")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (q *QuerySmsSignRequest) DoActionWithException() (resp *QuerySmsSignResponse, err error) {
+	if q != nil && q.Request != nil {
+		resp := &QuerySmsSignResponse{}
+		body, httpCode, err := q.Request.Do("QuerySmsSign")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("QuerySmsSignRequest is nil")
+}
+
+// QuerySmsSign 查询短信签名申请状态接口
+// signName 必填 - 签名名称
+func QuerySmsSign(signName string) *QuerySmsSignRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "QuerySmsSign")
+
+	r := &QuerySmsSignRequest{Request: req}
+	r.SetSignName(signName) // 必填 - 签名名称
+	return r
+}
@@ -0,0 +1,137 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// QuerySmsTemplateResponse 查询短信模板申请状态接口服务器响应
+type QuerySmsTemplateResponse struct {
+	ErrorMessage
+	TemplateCode    *string         `json:"TemplateCode,omitempty"`    // 短信模板CODE
+	TemplateName    *string         `json:"TemplateName,omitempty"`    // 模板名称
+	TemplateType    *TemplateType   `json:"TemplateType,omitempty"`    // 短信类型
+	TemplateContent *string         `json:"TemplateContent,omitempty"` // 模板内容
+	TemplateStatus  *TemplateStatus `json:"TemplateStatus,omitempty"`  // 模板审核状态
+	Reason          *string         `json:"Reason,omitempty"`          // 审核备注, 审核失败时为失败原因
+	CreateDate      *string         `json:"CreateDate,omitempty"`      // 短信模板的创建日期和时间
+}
+
+// GetTemplateCode 获取短信模板CODE
+func (q *QuerySmsTemplateResponse) GetTemplateCode() string {
+	if q != nil && q.TemplateCode != nil {
+		return *q.TemplateCode
+	}
+	return ""
+}
+
+// GetTemplateName 获取模板名称
+func (q *QuerySmsTemplateResponse) GetTemplateName() string {
+	if q != nil && q.TemplateName != nil {
+		return *q.TemplateName
+	}
+	return ""
+}
+
+// GetTemplateType 获取短信类型
+func (q *QuerySmsTemplateResponse) GetTemplateType() TemplateType {
+	if q != nil && q.TemplateType != nil {
+		return *q.TemplateType
+	}
+	return TemplateTypeVerification
+}
+
+// GetTemplateContent 获取模板内容
+func (q *QuerySmsTemplateResponse) GetTemplateContent() string {
+	if q != nil && q.TemplateContent != nil {
+		return *q.TemplateContent
+	}
+	return ""
+}
+
+// GetTemplateStatus 获取模板审核状态
+func (q *QuerySmsTemplateResponse) GetTemplateStatus() TemplateStatus {
+	if q != nil && q.TemplateStatus != nil {
+		return *q.TemplateStatus
+	}
+	return TemplateStatusAuditing
+}
+
+// GetReason 获取审核备注
+func (q *QuerySmsTemplateResponse) GetReason() string {
+	if q != nil && q.Reason != nil {
+		return *q.Reason
+	}
+	return ""
+}
+
+// GetCreateDate 获取短信模板的创建日期和时间
+func (q *QuerySmsTemplateResponse) GetCreateDate() string {
+	if q != nil && q.CreateDate != nil {
+		return *q.CreateDate
+	}
+	return ""
+}
+
+// String 序列化成JSON字符串
+func (q QuerySmsTemplateResponse) String() string {
+	body, err := json.Marshal(q)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// QuerySmsTemplateRequest 查询短信模板申请状态接口请求
+type QuerySmsTemplateRequest struct {
+	Request *Request
+}
+
+// SetTemplateCode 设置短信模板CODE 必须
+func (q *QuerySmsTemplateRequest) SetTemplateCode(templateCode string) {
+	if q != nil && q.Request != nil {
+		q.Request.Put("TemplateCode", templateCode)
+	}
+}
+
+// GetTemplateCode 获取短信模板CODE
+func (q *QuerySmsTemplateRequest) GetTemplateCode() string {
+	if q != nil && q.Request != nil {
+		return q.Request.Get("TemplateCode")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (q *QuerySmsTemplateRequest) DoActionWithException() (resp *QuerySmsTemplateResponse, err error) {
+	if q != nil && q.Request != nil {
+		resp := &QuerySmsTemplateResponse{}
+		body, httpCode, err := q.Request.Do("QuerySmsTemplate")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("QuerySmsTemplateRequest is nil")
+}
+
+// QuerySmsTemplate 查询短信模板申请状态接口
+// templateCode 必填 - 短信模板CODE
+func QuerySmsTemplate(templateCode string) *QuerySmsTemplateRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "QuerySmsTemplate")
+
+	r := &QuerySmsTemplateRequest{Request: req}
+	r.SetTemplateCode(templateCode) // 必填 - 短信模板CODE
+	return r
+}
@@ -0,0 +1,127 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// DybaseEndPoint 云通信基础服务(dybaseapi)地址, 用于获取消息队列的临时访问凭证
+var DybaseEndPoint = "http://dybaseapi.aliyuncs.com/"
+
+// 云通信消息类型
+const (
+	MessageTypeSmsReport = "SmsReport" // 短信发送状态报告
+	MessageTypeSmsUp     = "SmsUp"     // 上行短信
+)
+
+// MessageTokenDTO 消息队列的临时访问凭证
+type MessageTokenDTO struct {
+	AccessKeyID     string `json:"AccessKeyId"`     // 临时AccessKeyId
+	AccessKeySecret string `json:"AccessKeySecret"` // 临时AccessKeySecret
+	SecurityToken   string `json:"SecurityToken"`   // 安全令牌
+	CreateTime      string `json:"CreateTime"`      // 创建时间
+	ExpireTime      string `json:"ExpireTime"`      // 过期时间
+}
+
+// GetExpireTime 解析过期时间, 时区为东八区
+func (m *MessageTokenDTO) GetExpireTime() (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 15:04:05", m.ExpireTime, CSTZone)
+}
+
+// QueryTokenForMnsQueueResponse 获取消息队列临时访问凭证接口服务器响应
+type QueryTokenForMnsQueueResponse struct {
+	ErrorMessage
+	MessageTokenDTO *MessageTokenDTO `json:"MessageTokenDTO,omitempty"` // 临时访问凭证
+}
+
+// GetMessageTokenDTO 获取临时访问凭证
+func (q *QueryTokenForMnsQueueResponse) GetMessageTokenDTO() *MessageTokenDTO {
+	if q != nil && q.MessageTokenDTO != nil {
+		return q.MessageTokenDTO
+	}
+	return nil
+}
+
+// String 序列化成JSON字符串
+func (q QueryTokenForMnsQueueResponse) String() string {
+	body, err := json.Marshal(q)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// QueryTokenForMnsQueueRequest 获取消息队列临时访问凭证接口请求
+type QueryTokenForMnsQueueRequest struct {
+	Request *Request
+}
+
+// SetMessageType 设置消息类型 必须, 取值 MessageTypeSmsReport 或 MessageTypeSmsUp
+func (q *QueryTokenForMnsQueueRequest) SetMessageType(messageType string) {
+	if q != nil && q.Request != nil {
+		q.Request.Put("MessageType", messageType)
+	}
+}
+
+// GetMessageType 获取消息类型
+func (q *QueryTokenForMnsQueueRequest) GetMessageType() string {
+	if q != nil && q.Request != nil {
+		return q.Request.Get("MessageType")
+	}
+	return ""
+}
+
+// SetQueueName 设置消息队列名称
+func (q *QueryTokenForMnsQueueRequest) SetQueueName(queueName string) {
+	if q != nil && q.Request != nil {
+		q.Request.Put("QueueName", queueName)
+	}
+}
+
+// GetQueueName 获取消息队列名称
+func (q *QueryTokenForMnsQueueRequest) GetQueueName() string {
+	if q != nil && q.Request != nil {
+		return q.Request.Get("QueueName")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (q *QueryTokenForMnsQueueRequest) DoActionWithException() (resp *QueryTokenForMnsQueueResponse, err error) {
+	if q != nil && q.Request != nil {
+		resp := &QueryTokenForMnsQueueResponse{}
+		body, httpCode, err := q.Request.Do("QueryTokenForMnsQueue")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("QueryTokenForMnsQueueRequest is nil")
+}
+
+// QueryTokenForMnsQueue 获取消息队列临时访问凭证接口
+// messageType 必填 - 消息类型, 取值 MessageTypeSmsReport 或 MessageTypeSmsUp
+// queueName 消息队列名称
+func QueryTokenForMnsQueue(messageType, queueName string) *QueryTokenForMnsQueueRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "QueryTokenForMnsQueue")
+	req.SetEndPoint(DybaseEndPoint)
+
+	r := &QueryTokenForMnsQueueRequest{Request: req}
+	r.SetMessageType(messageType) // 必填 - 消息类型
+	if queueName != "" {
+		r.SetQueueName(queueName) // 消息队列名称
+	}
+	return r
+}
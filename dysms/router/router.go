@@ -0,0 +1,258 @@
+// Package router Copyright 2016 The GiterLab Authors. All rights reserved.
+//
+// router 在多个短信服务账号之间按优先级或权重路由发送请求, 并在账号余额不足、被限流等情况下自动切换账号
+package router
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+)
+
+// 默认参数
+const (
+	DefaultEjectAfter    = 3           // 连续失败多少次后暂时摘除账号
+	DefaultEjectDuration = time.Minute // 账号被摘除的时长
+)
+
+// DefaultFailoverCodes 默认切换账号的错误码
+var DefaultFailoverCodes = []string{
+	"isv.AMOUNT_NOT_ENOUGH",      // 账户余额不足
+	"isv.OUT_OF_SERVICE",         // 业务停机
+	"isv.ACCOUNT_ABNORMAL",       // 账户异常
+	"isv.ACCOUNT_NOT_EXISTS",     // 账户不存在
+	"isv.BUSINESS_LIMIT_CONTROL", // 业务限流
+	"isv.DAY_LIMIT_CONTROL",      // 触发日发送限额
+	"isp.SYSTEM_ERROR",           // 系统错误
+	"isp.RAM_PERMISSION_DENY",    // RAM权限不足
+	"InvalidAccessKeyId.NotFound",
+	"SignatureDoesNotMatch",
+	"Throttling.User",
+}
+
+// ErrNoRoute 没有可用的账号
+var ErrNoRoute = errors.New("router: no route")
+
+// Strategy 路由策略
+type Strategy int
+
+// 路由策略取值
+const (
+	StrategyPriority Strategy = iota // 按 Priority 从小到大依次尝试
+	StrategyWeighted                 // 按 Weight 加权随机选择首选账号, 失败后按加权随机顺序尝试其他账号
+)
+
+// Route 一个短信服务账号
+type Route struct {
+	Name          string            // 名称, 在结果和统计信息中使用
+	Client        *dysms.Client     // 账号的accessid、accesskey、地域和服务地址, 见 dysms.NewClient
+	Priority      int               // 优先级, 越小越优先, StrategyPriority 时使用
+	Weight        int               // 权重, StrategyWeighted 时使用, 为0时不会被优先选择
+	SignNames     map[string]string // 签名映射, 将调用方的签名替换为该账号下审核通过的签名, 未列出的原样使用
+	TemplateCodes map[string]string // 模板映射, 将调用方的模板CODE替换为该账号下的模板CODE, 未列出的原样使用
+}
+
+// RouteMetrics 单个账号的统计信息
+type RouteMetrics struct {
+	Requests            int64     // 请求次数
+	Succeeded           int64     // 发送成功次数
+	Failed              int64     // 发送失败次数
+	Failovers           int64     // 失败后切换到其他账号的次数
+	ConsecutiveFailures int       // 连续失败次数
+	EjectedUntil        time.Time // 被摘除到何时, 零值表示未被摘除
+	LastError           string    // 最近一次失败的原因
+}
+
+// Result 发送结果
+type Result struct {
+	Route    string                 // 最终发送使用的账号名称, 所有账号都发送失败并切换完毕时为空
+	Response *dysms.SendSmsResponse // 最终发送的响应
+	Tried    []string               // 依次尝试过的账号名称
+}
+
+// Router 多账号路由客户端, 可安全地并发使用
+type Router struct {
+	Strategy               Strategy      // 路由策略
+	FailoverCodes          []string      // 切换账号的错误码, 为nil时使用 DefaultFailoverCodes
+	FailoverOnNetworkError bool          // 未收到响应时是否切换账号, 请求可能已被受理, 切换可能导致重复发送
+	EjectAfter             int           // 连续失败多少次后暂时摘除账号, 为0时使用 DefaultEjectAfter
+	EjectDuration          time.Duration // 账号被摘除的时长, 为0时使用 DefaultEjectDuration
+
+	mu      sync.Mutex
+	routes  []*Route
+	metrics map[*Route]*RouteMetrics // 统计信息保存在 Router 中, 同一个 Route 可用于多个 Router
+	rand    *rand.Rand
+}
+
+// New 创建一个多账号路由客户端
+func New(strategy Strategy, routes ...*Route) *Router {
+	metrics := make(map[*Route]*RouteMetrics, len(routes))
+	for _, route := range routes {
+		metrics[route] = &RouteMetrics{}
+	}
+	return &Router{
+		Strategy: strategy,
+		routes:   routes,
+		metrics:  metrics,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// failover 是否切换账号
+func (r *Router) failover(resp *dysms.SendSmsResponse) bool {
+	if resp == nil || resp.GetHTTPCode() == 0 {
+		return r.FailoverOnNetworkError
+	}
+	codes := r.FailoverCodes
+	if codes == nil {
+		codes = DefaultFailoverCodes
+	}
+	for _, code := range codes {
+		if resp.GetCode() == code {
+			return true
+		}
+	}
+	return false
+}
+
+// order 本次发送尝试账号的顺序, 被摘除的账号排在最后
+func (r *Router) order(now time.Time) []*Route {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var healthy, ejected []*Route
+	for _, route := range r.routes {
+		if now.Before(r.metrics[route].EjectedUntil) {
+			ejected = append(ejected, route)
+		} else {
+			healthy = append(healthy, route)
+		}
+	}
+	for _, routes := range [][]*Route{healthy, ejected} {
+		if r.Strategy == StrategyWeighted {
+			r.shuffle(routes)
+		} else {
+			sort.SliceStable(routes, func(i, j int) bool {
+				return routes[i].Priority < routes[j].Priority
+			})
+		}
+	}
+	return append(healthy, ejected...)
+}
+
+// shuffle 按权重加权随机排序
+func (r *Router) shuffle(routes []*Route) {
+	for i := range routes {
+		total := 0
+		for _, route := range routes[i:] {
+			total += route.Weight
+		}
+		if total <= 0 {
+			return
+		}
+		n := r.rand.Intn(total)
+		for j := i; j < len(routes); j++ {
+			if n -= routes[j].Weight; n < 0 {
+				routes[i], routes[j] = routes[j], routes[i]
+				break
+			}
+		}
+	}
+}
+
+// record 记录发送结果, failover 为是否为需要切换账号的错误, 连续出现达到阈值后摘除账号
+// switched 为是否切换到了其他账号, 最后一个账号失败时没有可切换的账号
+func (r *Router) record(route *Route, err error, failover, switched bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.metrics[route]
+	m.Requests++
+	if err == nil {
+		m.Succeeded++
+		m.ConsecutiveFailures = 0
+		return
+	}
+	m.Failed++
+	m.LastError = err.Error()
+	if !failover {
+		return
+	}
+	if switched {
+		m.Failovers++
+	}
+	m.ConsecutiveFailures++
+	ejectAfter := r.EjectAfter
+	if ejectAfter <= 0 {
+		ejectAfter = DefaultEjectAfter
+	}
+	if m.ConsecutiveFailures >= ejectAfter {
+		d := r.EjectDuration
+		if d <= 0 {
+			d = DefaultEjectDuration
+		}
+		m.EjectedUntil = time.Now().Add(d)
+		m.ConsecutiveFailures = 0
+	}
+}
+
+// send 使用指定账号发送短信
+func send(route *Route, businessID, phoneNumbers, signName, templateCode, templateParam string) (*dysms.SendSmsResponse, error) {
+	if s, ok := route.SignNames[signName]; ok {
+		signName = s
+	}
+	if t, ok := route.TemplateCodes[templateCode]; ok {
+		templateCode = t
+	}
+	req := dysms.SendSms(businessID, phoneNumbers, signName, templateCode, templateParam)
+	req.Request.SetClient(route.Client)
+	if route.Client != nil && route.Client.Region != "" {
+		req.Request.Put("RegionId", route.Client.Region)
+	}
+	resp, err := req.DoActionWithException()
+	if err != nil {
+		return resp, err
+	}
+	if resp.GetCode() != "OK" {
+		return resp, &resp.ErrorMessage
+	}
+	return resp, nil
+}
+
+// SendSms 发送短信, 首选账号返回 FailoverCodes 中的错误码时依次切换到其他账号
+// 参数与 dysms.SendSms 相同, signName 和 templateCode 会按账号的映射替换
+func (r *Router) SendSms(ctx context.Context, businessID, phoneNumbers, signName, templateCode, templateParam string) (*Result, error) {
+	result := &Result{}
+	err := ErrNoRoute
+	routes := r.order(time.Now())
+	for i, route := range routes {
+		if cerr := ctx.Err(); cerr != nil {
+			return result, cerr
+		}
+		var resp *dysms.SendSmsResponse
+		resp, err = send(route, businessID, phoneNumbers, signName, templateCode, templateParam)
+		failover := err != nil && r.failover(resp)
+		r.record(route, err, failover, failover && i < len(routes)-1)
+		result.Response = resp
+		result.Tried = append(result.Tried, route.Name)
+		if !failover {
+			result.Route = route.Name
+			return result, err
+		}
+	}
+	return result, err
+}
+
+// Metrics 各账号的统计信息, 以账号名称为键
+func (r *Router) Metrics() map[string]RouteMetrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	metrics := make(map[string]RouteMetrics, len(r.routes))
+	for _, route := range r.routes {
+		metrics[route.Name] = *r.metrics[route]
+	}
+	return metrics
+}
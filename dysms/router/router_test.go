@@ -0,0 +1,89 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+)
+
+func Test_Router(t *testing.T) {
+	requests := make(map[string]int)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		account := q.Get("AccessKeyId")
+		requests[account]++
+		switch {
+		case account == "a":
+			w.WriteHeader(400)
+			w.Write([]byte(`{"RequestId":"RA","Code":"isv.AMOUNT_NOT_ENOUGH","Message":"amount not enough"}`))
+		case q.Get("PhoneNumbers") == "1":
+			w.WriteHeader(400)
+			w.Write([]byte(`{"RequestId":"RB","Code":"isv.MOBILE_NUMBER_ILLEGAL","Message":"illegal"}`))
+		default:
+			fmt.Fprintf(w, `{"RequestId":"RB","Code":"OK","Message":"OK","BizId":"%s-%s"}`, q.Get("SignName"), q.Get("RegionId"))
+		}
+	}))
+	defer ts.Close()
+
+	newClient := func(id, region string) *dysms.Client {
+		c := dysms.NewClient(id, id+"Secret")
+		c.SetEndPoint(ts.URL + "/")
+		c.SetRegion(region)
+		return c
+	}
+	r := New(StrategyPriority,
+		&Route{Name: "B", Client: newClient("b", "cn-shanghai"), Priority: 2, SignNames: map[string]string{"sign": "signB"}},
+		&Route{Name: "A", Client: newClient("a", "cn-hangzhou"), Priority: 1},
+	)
+	r.EjectAfter = 1
+
+	result, err := r.SendSms(context.Background(), "1", "13800000000", "sign", "SMS_1", "")
+	if err != nil || result.Route != "B" || len(result.Tried) != 2 || result.Tried[0] != "A" || result.Response.GetBizID() != "signB-cn-shanghai" {
+		t.Fatal("failover failed", result, err)
+	}
+	// A 已被摘除, 直接使用 B
+	result, err = r.SendSms(context.Background(), "1", "13800000000", "sign", "SMS_1", "")
+	if err != nil || result.Route != "B" || len(result.Tried) != 1 || requests["a"] != 1 {
+		t.Error("ejection failed", result, err, requests)
+	}
+	// 号码错误不切换账号
+	result, err = r.SendSms(context.Background(), "1", "1", "sign", "SMS_1", "")
+	if err == nil || result.Route != "B" || len(result.Tried) != 1 || result.Response.GetCode() != "isv.MOBILE_NUMBER_ILLEGAL" {
+		t.Error("non failover error failed", result, err)
+	}
+
+	metrics := r.Metrics()
+	if m := metrics["A"]; m.Requests != 1 || m.Failovers != 1 || m.EjectedUntil.IsZero() {
+		t.Error("metrics A failed", m)
+	}
+	if m := metrics["B"]; m.Requests != 3 || m.Succeeded != 2 || m.Failed != 1 || m.Failovers != 0 {
+		t.Error("metrics B failed", m)
+	}
+	// 所有账号都失败时没有最终使用的账号, 最后一个账号不计切换次数
+	only := New(StrategyPriority, &Route{Name: "A", Client: newClient("a", "cn-hangzhou")})
+	result, err = only.SendSms(context.Background(), "1", "13800000000", "sign", "SMS_1", "")
+	if err == nil || result.Route != "" || len(result.Tried) != 1 || result.Response.GetCode() != "isv.AMOUNT_NOT_ENOUGH" {
+		t.Error("total failure failed", result, err)
+	}
+	if m := only.Metrics()["A"]; m.Requests != 1 || m.Failed != 1 || m.Failovers != 0 || m.ConsecutiveFailures != 1 {
+		t.Error("metrics total failure failed", m)
+	}
+
+	// 统计信息保存在各自的 Router 中, 共用 Route 互不影响
+	shared := New(StrategyPriority, r.routes...)
+	if m := shared.Metrics()["A"]; m.Requests != 0 || !m.EjectedUntil.IsZero() {
+		t.Error("shared route metrics failed", m)
+	}
+
+	w := New(StrategyWeighted, &Route{Name: "A", Weight: 0}, &Route{Name: "B", Weight: 1})
+	for i := 0; i < 10; i++ {
+		if order := w.order(time.Now()); order[0].Name != "B" || order[1].Name != "A" {
+			t.Fatal("weighted order failed", order[0].Name)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// SendBatchCardSmsResponse 批量发送卡片短信接口服务器响应
+type SendBatchCardSmsResponse struct {
+	ErrorMessage
+	Data *CardSmsSendData `json:"Data,omitempty"` // 发送结果
+}
+
+// GetData 获取发送结果
+func (s *SendBatchCardSmsResponse) GetData() *CardSmsSendData {
+	if s != nil && s.Data != nil {
+		return s.Data
+	}
+	return nil
+}
+
+// String 序列化成JSON字符串
+func (s SendBatchCardSmsResponse) String() string {
+	body, err := json.Marshal(s)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// SendBatchCardSmsRequest 批量发送卡片短信接口请求
+type SendBatchCardSmsRequest struct {
+	Request *Request
+}
+
+// SetOutID 设置外部流水扩展字段
+func (s *SendBatchCardSmsRequest) SetOutID(outID string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("OutId", outID)
+	}
+}
+
+// GetOutID 获取外部流水扩展字段
+func (s *SendBatchCardSmsRequest) GetOutID() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("OutId")
+	}
+	return ""
+}
+
+// SetPhoneNumbers 设置接收短信的手机号列表 必须
+func (s *SendBatchCardSmsRequest) SetPhoneNumbers(phoneNumbers []string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("PhoneNumberJson", toJSONString(phoneNumbers))
+	}
+}
+
+// GetPhoneNumbers 获取接收短信的手机号列表, JSON字符串
+func (s *SendBatchCardSmsRequest) GetPhoneNumbers() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("PhoneNumberJson")
+	}
+	return ""
+}
+
+// SetSignNames 设置短信签名列表 必须, 与手机号列表一一对应
+func (s *SendBatchCardSmsRequest) SetSignNames(signNames []string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("SignNameJson", toJSONString(signNames))
+	}
+}
+
+// GetSignNames 获取短信签名列表, JSON字符串
+func (s *SendBatchCardSmsRequest) GetSignNames() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("SignNameJson")
+	}
+	return ""
+}
+
+// SetCardTemplateCode 设置卡片短信模板ID 必须
+func (s *SendBatchCardSmsRequest) SetCardTemplateCode(cardTemplateCode string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("CardTemplateCode", cardTemplateCode)
+	}
+}
+
+// GetCardTemplateCode 获取卡片短信模板ID
+func (s *SendBatchCardSmsRequest) GetCardTemplateCode() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("CardTemplateCode")
+	}
+	return ""
+}
+
+// SetCardTemplateParams 设置卡片短信动态参数列表, 与手机号列表一一对应
+func (s *SendBatchCardSmsRequest) SetCardTemplateParams(cardTemplateParams []map[string]string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("CardTemplateParamJson", toJSONString(cardTemplateParams))
+	}
+}
+
+// GetCardTemplateParams 获取卡片短信动态参数列表, JSON字符串
+func (s *SendBatchCardSmsRequest) GetCardTemplateParams() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("CardTemplateParamJson")
+	}
+	return ""
+}
+
+// SetSmsUpExtendCodes 设置上行短信扩展码列表, 与手机号列表一一对应
+func (s *SendBatchCardSmsRequest) SetSmsUpExtendCodes(smsUpExtendCodes []string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("SmsUpExtendCodeJson", toJSONString(smsUpExtendCodes))
+	}
+}
+
+// GetSmsUpExtendCodes 获取上行短信扩展码列表, JSON字符串
+func (s *SendBatchCardSmsRequest) GetSmsUpExtendCodes() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("SmsUpExtendCodeJson")
+	}
+	return ""
+}
+
+// SetFallback 设置不支持卡片短信时的回落方式
+// fallbackType 为 CardFallbackSMS 时, templateCode/templateParams 为文本短信模板及参数列表
+// fallbackType 为 CardFallbackDigitalSMS 时, templateCode/templateParams 为数字短信模板及参数列表
+// fallbackType 为 CardFallbackNone 时, 忽略 templateCode/templateParams
+func (s *SendBatchCardSmsRequest) SetFallback(fallbackType CardFallbackType, templateCode string, templateParams []map[string]string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("FallbackType", string(fallbackType))
+		switch fallbackType {
+		case CardFallbackSMS:
+			s.Request.Put("SmsTemplateCode", templateCode)
+			if len(templateParams) > 0 {
+				s.Request.Put("SmsTemplateParamJson", toJSONString(templateParams))
+			}
+		case CardFallbackDigitalSMS:
+			s.Request.Put("DigitalTemplateCode", templateCode)
+			if len(templateParams) > 0 {
+				s.Request.Put("DigitalTemplateParamJson", toJSONString(templateParams))
+			}
+		}
+	}
+}
+
+// GetFallbackType 获取回落类型
+func (s *SendBatchCardSmsRequest) GetFallbackType() CardFallbackType {
+	if s != nil && s.Request != nil {
+		return CardFallbackType(s.Request.Get("FallbackType"))
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (s *SendBatchCardSmsRequest) DoActionWithException() (resp *SendBatchCardSmsResponse, err error) {
+	if s != nil && s.Request != nil {
+		resp := &SendBatchCardSmsResponse{}
+		body, httpCode, err := s.Request.DoPost("SendBatchCardSms")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("SendBatchCardSmsRequest is nil")
+}
+
+// SendBatchCardSms 批量发送卡片短信接口
+// businessID 设置业务请求流水号
+// phoneNumbers 必填 - 接收短信的手机号列表
+// signNames 必填 - 短信签名列表, 与手机号列表一一对应
+// cardTemplateCode 必填 - 卡片短信模板ID
+// cardTemplateParams 卡片短信动态参数列表, 与手机号列表一一对应
+// fallbackType 必填 - 回落类型
+// fallbackTemplateCode 回落短信模板ID, 回落类型为 CardFallbackNone 时可为空
+// fallbackTemplateParams 回落短信模板参数列表, 与手机号列表一一对应
+func SendBatchCardSms(businessID string, phoneNumbers, signNames []string, cardTemplateCode string, cardTemplateParams []map[string]string, fallbackType CardFallbackType, fallbackTemplateCode string, fallbackTemplateParams []map[string]string) *SendBatchCardSmsRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "SendBatchCardSms")
+
+	r := &SendBatchCardSmsRequest{Request: req}
+	if businessID != "" {
+		r.SetOutID(businessID) // 业务请求流水号
+	}
+	r.SetPhoneNumbers(phoneNumbers)         // 必填 - 接收短信的手机号列表
+	r.SetSignNames(signNames)               // 必填 - 短信签名列表
+	r.SetCardTemplateCode(cardTemplateCode) // 必填 - 卡片短信模板ID
+	if len(cardTemplateParams) > 0 {
+		r.SetCardTemplateParams(cardTemplateParams) // 卡片短信动态参数列表
+	}
+	r.SetFallback(fallbackType, fallbackTemplateCode, fallbackTemplateParams) // 必填 - 回落类型
+	return r
+}
@@ -0,0 +1,185 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// SendCardSmsResponse 发送卡片短信接口服务器响应
+type SendCardSmsResponse struct {
+	ErrorMessage
+	Data *CardSmsSendData `json:"Data,omitempty"` // 发送结果
+}
+
+// GetData 获取发送结果
+func (s *SendCardSmsResponse) GetData() *CardSmsSendData {
+	if s != nil && s.Data != nil {
+		return s.Data
+	}
+	return nil
+}
+
+// String 序列化成JSON字符串
+func (s SendCardSmsResponse) String() string {
+	body, err := json.Marshal(s)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// SendCardSmsRequest 发送卡片短信接口请求
+type SendCardSmsRequest struct {
+	Request *Request
+}
+
+// SetOutID 设置外部流水扩展字段
+func (s *SendCardSmsRequest) SetOutID(outID string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("OutId", outID)
+	}
+}
+
+// GetOutID 获取外部流水扩展字段
+func (s *SendCardSmsRequest) GetOutID() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("OutId")
+	}
+	return ""
+}
+
+// SetSignName 设置短信签名 必须
+func (s *SendCardSmsRequest) SetSignName(signName string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("SignName", signName)
+	}
+}
+
+// GetSignName 获取短信签名
+func (s *SendCardSmsRequest) GetSignName() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("SignName")
+	}
+	return ""
+}
+
+// SetCardTemplateCode 设置卡片短信模板ID 必须
+func (s *SendCardSmsRequest) SetCardTemplateCode(cardTemplateCode string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("CardTemplateCode", cardTemplateCode)
+	}
+}
+
+// GetCardTemplateCode 获取卡片短信模板ID
+func (s *SendCardSmsRequest) GetCardTemplateCode() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("CardTemplateCode")
+	}
+	return ""
+}
+
+// SetCardObjects 设置卡片短信发送对象 必须
+func (s *SendCardSmsRequest) SetCardObjects(cardObjects []CardObject) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("CardObjects", toJSONString(cardObjects))
+	}
+}
+
+// GetCardObjects 获取卡片短信发送对象, JSON字符串
+func (s *SendCardSmsRequest) GetCardObjects() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("CardObjects")
+	}
+	return ""
+}
+
+// SetSmsUpExtendCode 设置上行短信扩展码
+func (s *SendCardSmsRequest) SetSmsUpExtendCode(smsUpExtendCode string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("SmsUpExtendCode", smsUpExtendCode)
+	}
+}
+
+// GetSmsUpExtendCode 获取上行短信扩展码
+func (s *SendCardSmsRequest) GetSmsUpExtendCode() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("SmsUpExtendCode")
+	}
+	return ""
+}
+
+// SetFallback 设置不支持卡片短信时的回落方式
+// fallbackType 为 CardFallbackSMS 时, templateCode/templateParam 为文本短信模板及参数
+// fallbackType 为 CardFallbackDigitalSMS 时, templateCode/templateParam 为数字短信模板及参数
+// fallbackType 为 CardFallbackNone 时, 忽略 templateCode/templateParam
+func (s *SendCardSmsRequest) SetFallback(fallbackType CardFallbackType, templateCode, templateParam string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("FallbackType", string(fallbackType))
+		switch fallbackType {
+		case CardFallbackSMS:
+			s.Request.Put("SmsTemplateCode", templateCode)
+			if templateParam != "" {
+				s.Request.Put("SmsTemplateParam", templateParam)
+			}
+		case CardFallbackDigitalSMS:
+			s.Request.Put("DigitalTemplateCode", templateCode)
+			if templateParam != "" {
+				s.Request.Put("DigitalTemplateParam", templateParam)
+			}
+		}
+	}
+}
+
+// GetFallbackType 获取回落类型
+func (s *SendCardSmsRequest) GetFallbackType() CardFallbackType {
+	if s != nil && s.Request != nil {
+		return CardFallbackType(s.Request.Get("FallbackType"))
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (s *SendCardSmsRequest) DoActionWithException() (resp *SendCardSmsResponse, err error) {
+	if s != nil && s.Request != nil {
+		resp := &SendCardSmsResponse{}
+		body, httpCode, err := s.Request.DoPost("SendCardSms")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("SendCardSmsRequest is nil")
+}
+
+// SendCardSms 发送卡片短信接口
+// businessID 设置业务请求流水号
+// signName 必填 - 短信签名
+// cardTemplateCode 必填 - 卡片短信模板ID
+// cardObjects 必填 - 卡片短信发送对象
+// fallbackType 必填 - 回落类型
+// fallbackTemplateCode 回落短信模板ID, 回落类型为 CardFallbackNone 时可为空
+// fallbackTemplateParam 回落短信模板变量参数
+func SendCardSms(businessID, signName, cardTemplateCode string, cardObjects []CardObject, fallbackType CardFallbackType, fallbackTemplateCode, fallbackTemplateParam string) *SendCardSmsRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "SendCardSms")
+
+	r := &SendCardSmsRequest{Request: req}
+	if businessID != "" {
+		r.SetOutID(businessID) // 业务请求流水号
+	}
+	r.SetSignName(signName)                                                  // 必填 - 短信签名
+	r.SetCardTemplateCode(cardTemplateCode)                                  // 必填 - 卡片短信模板ID
+	r.SetCardObjects(cardObjects)                                            // 必填 - 卡片短信发送对象
+	r.SetFallback(fallbackType, fallbackTemplateCode, fallbackTemplateParam) // 必填 - 回落类型
+	return r
+}
@@ -0,0 +1,141 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrSendDateOutOfRange 短信发送日期超出可查询范围
+var ErrSendDateOutOfRange = errors.New("send date should be within the last 30 days")
+
+// sendDetailsDays QuerySendDetails 接口支持查询的天数
+const sendDetailsDays = 30
+
+// CSTZone 中国标准时间(Asia/Shanghai), 短信服务接口和推送消息中的时间均为东八区时间
+var CSTZone = loadCSTZone()
+
+// loadCSTZone 加载 Asia/Shanghai 时区, 系统缺少时区数据时使用东八区固定时区
+func loadCSTZone() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Shanghai"); err == nil {
+		return loc
+	}
+	return time.FixedZone("CST", 8*3600)
+}
+
+// sendDetailTimeLayout 短信发送记录中发送时间和接收时间的格式
+const sendDetailTimeLayout = "2006-01-02 15:04:05"
+
+// SendStatus 短信发送状态
+type SendStatus int
+
+// 短信发送状态取值
+const (
+	SendStatusWaiting   SendStatus = 1 // 1：等待回执
+	SendStatusFailed    SendStatus = 2 // 2：发送失败
+	SendStatusDelivered SendStatus = 3 // 3：发送成功
+)
+
+// String 短信发送状态描述
+func (s SendStatus) String() string {
+	switch s {
+	case SendStatusWaiting:
+		return "等待回执"
+	case SendStatusFailed:
+		return "发送失败"
+	case SendStatusDelivered:
+		return "发送成功"
+	}
+	return "SendStatus(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Final 是否为最终状态
+func (s SendStatus) Final() bool {
+	return s == SendStatusFailed || s == SendStatusDelivered
+}
+
+// GetSendStatus 获取短信发送状态
+func (s SmsSendDetailDTO) GetSendStatus() SendStatus {
+	return SendStatus(s.SendStatus)
+}
+
+// GetSendDate 获取发送时间, 时区为中国标准时间
+func (s SmsSendDetailDTO) GetSendDate() (time.Time, error) {
+	return time.ParseInLocation(sendDetailTimeLayout, s.SendDate, CSTZone)
+}
+
+// GetReceiveDate 获取接收时间, 时区为中国标准时间, 尚未收到回执时返回零值
+func (s SmsSendDetailDTO) GetReceiveDate() (time.Time, error) {
+	if s.ReceiveDate == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(sendDetailTimeLayout, s.ReceiveDate, CSTZone)
+}
+
+// formatSendDate 将发送日期转换为中国标准时间的yyyyMMdd格式, 超过30天的日期返回错误
+func formatSendDate(sendDate time.Time) (string, error) {
+	y, m, d := time.Now().In(CSTZone).Date()
+	earliest := time.Date(y, m, d, 0, 0, 0, 0, CSTZone).AddDate(0, 0, -sendDetailsDays)
+	if sendDate.Before(earliest) {
+		return "", ErrSendDateOutOfRange
+	}
+	return sendDate.In(CSTZone).Format("20060102"), nil
+}
+
+// SetSendDateTime 设置短信发送日期, 按中国标准时间取日期, 仅支持最近30天
+func (q *QuerySendDetailsRequest) SetSendDateTime(sendDate time.Time) error {
+	date, err := formatSendDate(sendDate)
+	if err != nil {
+		return err
+	}
+	q.SetSendDate(date)
+	return nil
+}
+
+// GetSendDateTime 获取短信发送日期, 时区为中国标准时间
+func (q *QuerySendDetailsRequest) GetSendDateTime() (time.Time, error) {
+	return time.ParseInLocation("20060102", q.GetSendDate(), CSTZone)
+}
+
+// SetPageSizeInt 设置页大小, 超过 QuerySendDetailsMaxPageSize 时按上限设置
+func (q *QuerySendDetailsRequest) SetPageSizeInt(pageSize int) {
+	if pageSize > QuerySendDetailsMaxPageSize {
+		pageSize = QuerySendDetailsMaxPageSize
+	}
+	q.SetPageSize(strconv.Itoa(pageSize))
+}
+
+// GetPageSizeInt 获取页大小
+func (q *QuerySendDetailsRequest) GetPageSizeInt() int {
+	n, _ := strconv.Atoi(q.GetPageSize())
+	return n
+}
+
+// SetCurrentPageInt 设置当前页码, 从1开始计数
+func (q *QuerySendDetailsRequest) SetCurrentPageInt(currentPage int) {
+	q.SetCurrentPage(strconv.Itoa(currentPage))
+}
+
+// GetCurrentPageInt 获取当前页码
+func (q *QuerySendDetailsRequest) GetCurrentPageInt() int {
+	n, _ := strconv.Atoi(q.GetCurrentPage())
+	return n
+}
+
+// QuerySendDetailsByDate 短信发送记录查询接口, 使用类型化的参数
+// bizID 可选 - 流水号
+// phoneNumber 查询的手机号码
+// pageSize 必填 - 页大小, 最大50
+// currentPage 必填 - 当前页码从1开始计数
+// sendDate 必填 - 发送日期 支持30天内记录查询
+func QuerySendDetailsByDate(bizID, phoneNumber string, pageSize, currentPage int, sendDate time.Time) (*QuerySendDetailsRequest, error) {
+	date, err := formatSendDate(sendDate)
+	if err != nil {
+		return nil, err
+	}
+	r := QuerySendDetails(bizID, phoneNumber, "", "", date)
+	r.SetPageSizeInt(pageSize)
+	r.SetCurrentPageInt(currentPage)
+	return r, nil
+}
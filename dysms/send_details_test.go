@@ -0,0 +1,34 @@
+package dysms
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func Test_SmsSendDetailDTO(t *testing.T) {
+	var d SmsSendDetailDTO
+	json.Unmarshal([]byte(`{"SendStatus":3,"SendDate":"2017-07-12 10:42:19","ReceiveDate":"2017-07-12 10:42:23"}`), &d)
+	if d.GetSendStatus() != SendStatusDelivered || d.GetSendStatus().String() != "发送成功" || !d.GetSendStatus().Final() {
+		t.Error("GetSendStatus failed", d.GetSendStatus())
+	}
+	sendDate, err := d.GetSendDate()
+	if err != nil || !sendDate.Equal(time.Date(2017, 7, 12, 2, 42, 19, 0, time.UTC)) {
+		t.Error("GetSendDate failed", sendDate, err)
+	}
+	receiveDate, err := d.GetReceiveDate()
+	if err != nil || receiveDate.Sub(sendDate) != 4*time.Second {
+		t.Error("GetReceiveDate failed", receiveDate, err)
+	}
+}
+
+func Test_QuerySendDetailsByDate(t *testing.T) {
+	now := time.Now()
+	r, err := QuerySendDetailsByDate("", "13800000000", 100, 2, now)
+	if err != nil || r.GetPageSizeInt() != 50 || r.GetCurrentPageInt() != 2 || r.GetSendDate() != now.In(CSTZone).Format("20060102") {
+		t.Error("QuerySendDetailsByDate failed", err)
+	}
+	if _, err := QuerySendDetailsByDate("", "13800000000", 10, 1, now.AddDate(0, 0, -31)); err != ErrSendDateOutOfRange {
+		t.Error("QuerySendDetailsByDate range check failed", err)
+	}
+}
@@ -0,0 +1,170 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// SendMessageToGlobeResponse 发送国际/港澳台短信接口服务器响应
+type SendMessageToGlobeResponse struct {
+	GlobeErrorMessage
+	MessageID    *string       `json:"MessageId,omitempty"`    // 短信ID
+	To           *string       `json:"To,omitempty"`           // 接收号码
+	From         *string       `json:"From,omitempty"`         // 发送方标识
+	Segments     *json.Number  `json:"Segments,omitempty"`     // 短信计费条数
+	NumberDetail *NumberDetail `json:"NumberDetail,omitempty"` // 号码详情
+}
+
+// GetMessageID 获取短信ID
+func (s *SendMessageToGlobeResponse) GetMessageID() string {
+	if s != nil && s.MessageID != nil {
+		return *s.MessageID
+	}
+	return ""
+}
+
+// GetTo 获取接收号码
+func (s *SendMessageToGlobeResponse) GetTo() string {
+	if s != nil && s.To != nil {
+		return *s.To
+	}
+	return ""
+}
+
+// GetFrom 获取发送方标识
+func (s *SendMessageToGlobeResponse) GetFrom() string {
+	if s != nil && s.From != nil {
+		return *s.From
+	}
+	return ""
+}
+
+// GetSegments 获取短信计费条数
+func (s *SendMessageToGlobeResponse) GetSegments() int {
+	if s != nil {
+		return globeSegments(s.Segments)
+	}
+	return 0
+}
+
+// GetNumberDetail 获取号码详情
+func (s *SendMessageToGlobeResponse) GetNumberDetail() *NumberDetail {
+	if s != nil && s.NumberDetail != nil {
+		return s.NumberDetail
+	}
+	return nil
+}
+
+// String 序列化成JSON字符串
+func (s SendMessageToGlobeResponse) String() string {
+	body, err := json.Marshal(s)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// SendMessageToGlobeRequest 发送国际/港澳台短信接口请求
+type SendMessageToGlobeRequest struct {
+	Request *Request
+}
+
+// SetTo 设置接收号码 必须, 格式为国际区号+号码, 如6591234567
+func (s *SendMessageToGlobeRequest) SetTo(to string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("To", to)
+	}
+}
+
+// GetTo 获取接收号码
+func (s *SendMessageToGlobeRequest) GetTo() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("To")
+	}
+	return ""
+}
+
+// SetFrom 设置发送方标识 可选
+func (s *SendMessageToGlobeRequest) SetFrom(from string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("From", from)
+	}
+}
+
+// GetFrom 获取发送方标识
+func (s *SendMessageToGlobeRequest) GetFrom() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("From")
+	}
+	return ""
+}
+
+// SetMessage 设置短信内容 必须
+func (s *SendMessageToGlobeRequest) SetMessage(message string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("Message", message)
+	}
+}
+
+// GetMessage 获取短信内容
+func (s *SendMessageToGlobeRequest) GetMessage() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("Message")
+	}
+	return ""
+}
+
+// SetTaskID 设置任务ID 可选
+func (s *SendMessageToGlobeRequest) SetTaskID(taskID string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("TaskId", taskID)
+	}
+}
+
+// GetTaskID 获取任务ID
+func (s *SendMessageToGlobeRequest) GetTaskID() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("TaskId")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (s *SendMessageToGlobeRequest) DoActionWithException() (resp *SendMessageToGlobeResponse, err error) {
+	if s != nil && s.Request != nil {
+		resp := &SendMessageToGlobeResponse{}
+		body, httpCode, err := s.Request.DoPost("SendMessageToGlobe")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		return resp, resp.globeError(httpCode)
+	}
+	return nil, errors.New("SendMessageToGlobeRequest is nil")
+}
+
+// SendMessageToGlobe 发送国际/港澳台短信接口
+// to 必填 - 接收号码, E.164格式, 如+6591234567
+// from 可选 - 发送方标识
+// message 必填 - 短信内容
+func SendMessageToGlobe(to, from, message string) (*SendMessageToGlobeRequest, error) {
+	n, err := ParseE164PhoneNumber(to)
+	if err != nil {
+		return nil, err
+	}
+	req := newGlobeRequset()
+	req.Put("Action", "SendMessageToGlobe")
+
+	r := &SendMessageToGlobeRequest{Request: req}
+	r.SetTo(n) // 必填 - 接收号码
+	if from != "" {
+		r.SetFrom(from) // 可选 - 发送方标识
+	}
+	r.SetMessage(message) // 必填 - 短信内容
+	return r, nil
+}
@@ -0,0 +1,178 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// SendMessageWithTemplateResponse 使用模板发送国际/港澳台短信接口服务器响应
+type SendMessageWithTemplateResponse struct {
+	GlobeErrorMessage
+	MessageID    *string       `json:"MessageId,omitempty"`    // 短信ID
+	To           *string       `json:"To,omitempty"`           // 接收号码
+	Segments     *json.Number  `json:"Segments,omitempty"`     // 短信计费条数
+	NumberDetail *NumberDetail `json:"NumberDetail,omitempty"` // 号码详情
+}
+
+// GetMessageID 获取短信ID
+func (s *SendMessageWithTemplateResponse) GetMessageID() string {
+	if s != nil && s.MessageID != nil {
+		return *s.MessageID
+	}
+	return ""
+}
+
+// GetTo 获取接收号码
+func (s *SendMessageWithTemplateResponse) GetTo() string {
+	if s != nil && s.To != nil {
+		return *s.To
+	}
+	return ""
+}
+
+// GetSegments 获取短信计费条数
+func (s *SendMessageWithTemplateResponse) GetSegments() int {
+	if s != nil {
+		return globeSegments(s.Segments)
+	}
+	return 0
+}
+
+// GetNumberDetail 获取号码详情
+func (s *SendMessageWithTemplateResponse) GetNumberDetail() *NumberDetail {
+	if s != nil && s.NumberDetail != nil {
+		return s.NumberDetail
+	}
+	return nil
+}
+
+// String 序列化成JSON字符串
+func (s SendMessageWithTemplateResponse) String() string {
+	body, err := json.Marshal(s)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// SendMessageWithTemplateRequest 使用模板发送国际/港澳台短信接口请求
+type SendMessageWithTemplateRequest struct {
+	Request *Request
+}
+
+// SetTo 设置接收号码 必须, 格式为国际区号+号码, 如6591234567
+func (s *SendMessageWithTemplateRequest) SetTo(to string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("To", to)
+	}
+}
+
+// GetTo 获取接收号码
+func (s *SendMessageWithTemplateRequest) GetTo() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("To")
+	}
+	return ""
+}
+
+// SetFrom 设置短信签名 必须
+func (s *SendMessageWithTemplateRequest) SetFrom(from string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("From", from)
+	}
+}
+
+// GetFrom 获取短信签名
+func (s *SendMessageWithTemplateRequest) GetFrom() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("From")
+	}
+	return ""
+}
+
+// SetTemplateCode 设置短信模板CODE 必须
+func (s *SendMessageWithTemplateRequest) SetTemplateCode(templateCode string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("TemplateCode", templateCode)
+	}
+}
+
+// GetTemplateCode 获取短信模板CODE
+func (s *SendMessageWithTemplateRequest) GetTemplateCode() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("TemplateCode")
+	}
+	return ""
+}
+
+// SetTemplateParam 设置短信模板变量替换JSON串
+func (s *SendMessageWithTemplateRequest) SetTemplateParam(templateParam string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("TemplateParam", templateParam)
+	}
+}
+
+// GetTemplateParam 获取短信模板变量替换JSON串
+func (s *SendMessageWithTemplateRequest) GetTemplateParam() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("TemplateParam")
+	}
+	return ""
+}
+
+// SetSmsUpExtendCode 设置上行短信扩展码
+func (s *SendMessageWithTemplateRequest) SetSmsUpExtendCode(smsUpExtendCode string) {
+	if s != nil && s.Request != nil {
+		s.Request.Put("SmsUpExtendCode", smsUpExtendCode)
+	}
+}
+
+// GetSmsUpExtendCode 获取上行短信扩展码
+func (s *SendMessageWithTemplateRequest) GetSmsUpExtendCode() string {
+	if s != nil && s.Request != nil {
+		return s.Request.Get("SmsUpExtendCode")
+	}
+	return ""
+}
+
+// DoActionWithException 发起HTTP请求
+func (s *SendMessageWithTemplateRequest) DoActionWithException() (resp *SendMessageWithTemplateResponse, err error) {
+	if s != nil && s.Request != nil {
+		resp := &SendMessageWithTemplateResponse{}
+		body, httpCode, err := s.Request.DoPost("SendMessageWithTemplate")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		return resp, resp.globeError(httpCode)
+	}
+	return nil, errors.New("SendMessageWithTemplateRequest is nil")
+}
+
+// SendMessageWithTemplate 使用模板发送国际/港澳台短信接口
+// to 必填 - 接收号码, E.164格式, 如+6591234567
+// from 必填 - 短信签名
+// templateCode 必填 - 短信模板CODE
+// templateParam 短信模板变量参数
+func SendMessageWithTemplate(to, from, templateCode, templateParam string) (*SendMessageWithTemplateRequest, error) {
+	n, err := ParseE164PhoneNumber(to)
+	if err != nil {
+		return nil, err
+	}
+	req := newGlobeRequset()
+	req.Put("Action", "SendMessageWithTemplate")
+
+	r := &SendMessageWithTemplateRequest{Request: req}
+	r.SetTo(n)                      // 必填 - 接收号码
+	r.SetFrom(from)                 // 必填 - 短信签名
+	r.SetTemplateCode(templateCode) // 必填 - 短信模板CODE
+	if templateParam != "" {
+		r.SetTemplateParam(templateParam) // 短信模板变量参数
+	}
+	return r, nil
+}
@@ -32,6 +32,9 @@ func (s SendSmsResponse) String() string {
 // SendSmsRequest 发送短信接口请求
 type SendSmsRequest struct {
 	Request *Request
+
+	international bool              // 国际短信模式, 发送前校验短信模板类型
+	registry      *TemplateRegistry // 短信模板注册表, 发送前校验模板变量
 }
 
 // SetOutID 设置外部流水扩展字段
@@ -162,6 +165,19 @@ func (s *SendSmsRequest) GetTemplateParam() string {
 func (s *SendSmsRequest) DoActionWithException() (resp *SendSmsResponse, err error) {
 	if s != nil && s.Request != nil {
 		resp := &SendSmsResponse{}
+		if AutoNormalizePhoneNumbers {
+			if err := s.NormalizePhoneNumbers(); err != nil {
+				return resp, err
+			}
+		}
+		if s.international {
+			if err := checkInternationalTemplate(s.Request, s.GetTemplateCode()); err != nil {
+				return resp, err
+			}
+		}
+		if err := s.ValidateTemplateParam(); err != nil {
+			return resp, err
+		}
 		body, httpCode, err := s.Request.Do("SendSms")
 		resp.SetHTTPCode(httpCode)
 		if err != nil {
@@ -0,0 +1,129 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ShortURLStatus 短链服务状态
+type ShortURLStatus string
+
+// 短链服务状态取值
+const (
+	ShortURLStatusExpired   ShortURLStatus = "expired"   // 失效
+	ShortURLStatusEffective ShortURLStatus = "effective" // 有效
+	ShortURLStatusAudit     ShortURLStatus = "audit"     // 审核中
+	ShortURLStatusReject    ShortURLStatus = "reject"    // 审核不通过
+)
+
+// ShortURLData 短链信息
+type ShortURLData struct {
+	SourceURL          string         `json:"SourceUrl"`                    // 原始链接地址
+	ShortURL           string         `json:"ShortUrl"`                     // 短链地址
+	ExpireDate         string         `json:"ExpireDate"`                   // 短链服务失效时间
+	CreateDate         string         `json:"CreateDate,omitempty"`         // 短链创建时间
+	ShortURLName       string         `json:"ShortUrlName,omitempty"`       // 短链服务名称
+	ShortURLStatus     ShortURLStatus `json:"ShortUrlStatus,omitempty"`     // 短链服务状态
+	PageViewCount      json.Number    `json:"PageViewCount,omitempty"`      // 短链点击次数
+	UniqueVisitorCount json.Number    `json:"UniqueVisitorCount,omitempty"` // 短链独立访客数
+}
+
+// GetPageViewCount 短链点击次数
+func (d *ShortURLData) GetPageViewCount() int64 {
+	if d != nil {
+		n, _ := d.PageViewCount.Int64()
+		return n
+	}
+	return 0
+}
+
+// GetUniqueVisitorCount 短链独立访客数
+func (d *ShortURLData) GetUniqueVisitorCount() int64 {
+	if d != nil {
+		n, _ := d.UniqueVisitorCount.Int64()
+		return n
+	}
+	return 0
+}
+
+// GetExpireDate 解析短链服务失效时间, 时区为东八区
+func (d *ShortURLData) GetExpireDate() (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 15:04:05", d.ExpireDate, CSTZone)
+}
+
+// ShortURLShortener 将短信模板参数中的链接批量转换为短链, 相同的原始链接复用已申请的短链
+type ShortURLShortener struct {
+	ShortURLName  string // 短链服务名称, 不超过13个字符
+	EffectiveDays int    // 短链服务使用有效期, 取值30、60或90天
+
+	mu    sync.Mutex
+	cache map[string]ShortURLData
+}
+
+// NewShortURLShortener 创建一个短链转换器
+func NewShortURLShortener(shortURLName string, effectiveDays int) *ShortURLShortener {
+	return &ShortURLShortener{
+		ShortURLName:  shortURLName,
+		EffectiveDays: effectiveDays,
+		cache:         make(map[string]ShortURLData),
+	}
+}
+
+// Shorten 将一个原始链接转换为短链, 缓存中未失效的短链会被直接复用
+func (s *ShortURLShortener) Shorten(sourceURL string) (string, error) {
+	s.mu.Lock()
+	if s.cache == nil {
+		s.cache = make(map[string]ShortURLData)
+	}
+	if d, ok := s.cache[sourceURL]; ok {
+		if expire, err := d.GetExpireDate(); err != nil || time.Now().Before(expire) {
+			s.mu.Unlock()
+			return d.ShortURL, nil
+		}
+		delete(s.cache, sourceURL)
+	}
+	s.mu.Unlock()
+
+	resp, err := AddShortURL(sourceURL, s.ShortURLName, s.EffectiveDays).DoActionWithException()
+	if err != nil {
+		return "", err
+	}
+	d := resp.GetData()
+	if d == nil || d.ShortURL == "" {
+		return "", &resp.ErrorMessage
+	}
+
+	s.mu.Lock()
+	s.cache[sourceURL] = *d
+	s.mu.Unlock()
+	return d.ShortURL, nil
+}
+
+// ShortenTemplateParam 将短信模板参数中所有的链接值转换为短链, 返回新的模板参数, 原参数不会被修改
+func (s *ShortURLShortener) ShortenTemplateParam(templateParam map[string]string) (map[string]string, error) {
+	result := make(map[string]string, len(templateParam))
+	for k, v := range templateParam {
+		if isSourceURL(v) {
+			shortURL, err := s.Shorten(v)
+			if err != nil {
+				return nil, err
+			}
+			v = shortURL
+		}
+		result[k] = v
+	}
+	return result, nil
+}
+
+// isSourceURL 判断模板参数值是否为需要转换的链接
+func isSourceURL(s string) bool {
+	if !strings.HasPrefix(s, "http://") && !strings.HasPrefix(s, "https://") {
+		return false
+	}
+	u, err := url.Parse(s)
+	return err == nil && u.Host != ""
+}
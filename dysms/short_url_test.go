@@ -0,0 +1,54 @@
+package dysms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_ShortenTemplateParam(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("Action") != "AddShortUrl" || r.FormValue("SourceUrl") != "https://example.com/a?b=1&c=2" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		calls++
+		w.Write([]byte(`{"RequestId":"r1","Code":"OK","Message":"OK","Data":{"SourceUrl":"https://example.com/a?b=1&c=2","ExpireDate":"2099-10-15 11:49:23","ShortUrl":"http://t.cn/6y8uy7"}}`))
+	}))
+	defer ts.Close()
+	defer setTestEndPoint(ts.URL)()
+
+	s := NewShortURLShortener("test", 30)
+	param := map[string]string{"url": "https://example.com/a?b=1&c=2", "url2": "https://example.com/a?b=1&c=2", "name": "http"}
+	result, err := s.ShortenTemplateParam(param)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["url"] != "http://t.cn/6y8uy7" || result["url2"] != "http://t.cn/6y8uy7" || result["name"] != "http" {
+		t.Error("ShortenTemplateParam failed", result)
+	}
+	if param["url"] != "https://example.com/a?b=1&c=2" {
+		t.Error("ShortenTemplateParam modified the source param")
+	}
+	if calls != 1 {
+		t.Error("ShortenTemplateParam cache failed", calls)
+	}
+}
+
+func Test_QueryShortURLResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"RequestId":"r1","Code":"OK","Message":"OK","Data":{"SourceUrl":"https://example.com","ExpireDate":"2019-10-15 11:49:23","ShortUrl":"http://t.cn/6y8uy7","ShortUrlStatus":"effective","PageViewCount":"1200","UniqueVisitorCount":230}}`))
+	}))
+	defer ts.Close()
+	defer setTestEndPoint(ts.URL)()
+
+	resp, err := QueryShortURL("http://t.cn/6y8uy7").DoActionWithException()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := resp.GetData()
+	if d.ShortURLStatus != ShortURLStatusEffective || d.GetPageViewCount() != 1200 || d.GetUniqueVisitorCount() != 230 {
+		t.Error("QueryShortURL response failed", resp.String())
+	}
+}
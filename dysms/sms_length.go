@@ -0,0 +1,122 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// SmsEncoding 短信编码
+type SmsEncoding string
+
+// 短信编码取值
+const (
+	EncodingGSM7 SmsEncoding = "GSM-7" // GSM 7-bit 默认字母表, 仅国际短信使用
+	EncodingUCS2 SmsEncoding = "UCS-2" // UCS-2
+)
+
+// 计费规则
+const (
+	SinglePartLength     = 70  // 单条短信字符数上限
+	MultiPartLength      = 67  // 长短信拆分后每条的字符数
+	SinglePartLengthGSM7 = 160 // GSM-7编码单条短信字符数上限
+	MultiPartLengthGSM7  = 153 // GSM-7编码长短信拆分后每条的字符数
+)
+
+// gsm7Basic GSM 7-bit 默认字母表
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extension GSM 7-bit 扩展字母表, 每个字符占用2个字符位
+const gsm7Extension = "\f^{}\\[~]|€"
+
+// SmsLength 短信长度及计费条数
+type SmsLength struct {
+	Characters int         // 字符数, 包含签名及签名两侧的括号
+	Segments   int         // 计费条数
+	Encoding   SmsEncoding // 短信编码
+}
+
+// TotalSegments 发送给 recipients 个号码时的总计费条数
+func (l SmsLength) TotalSegments(recipients int) int {
+	return l.Segments * recipients
+}
+
+// CalcSmsLength 计算短信长度及计费条数
+// 短信内容为 【签名】+ 模板渲染后的内容, 70个字符以内计为1条, 超过70个字符时按每67个字符计为1条;
+// 国际短信内容为 [签名] + 模板渲染后的内容, 均为GSM 7-bit字符时按160/153个字符计费, 否则按UCS-2编码的70/67个字符计费
+func CalcSmsLength(signName, content string, international bool) SmsLength {
+	if !international {
+		text := content
+		if signName != "" {
+			text = "【" + signName + "】" + content
+		}
+		n := utf8.RuneCountInString(text)
+		return SmsLength{Characters: n, Segments: segments(n, SinglePartLength, MultiPartLength), Encoding: EncodingUCS2}
+	}
+
+	text := content
+	if signName != "" {
+		text = "[" + signName + "]" + content
+	}
+	if n, ok := gsm7Length(text); ok {
+		return SmsLength{Characters: n, Segments: segments(n, SinglePartLengthGSM7, MultiPartLengthGSM7), Encoding: EncodingGSM7}
+	}
+	n := len(utf16.Encode([]rune(text)))
+	return SmsLength{Characters: n, Segments: segments(n, SinglePartLength, MultiPartLength), Encoding: EncodingUCS2}
+}
+
+// gsm7Length 计算GSM 7-bit编码的字符位数, 包含非GSM字符时返回false
+func gsm7Length(text string) (int, bool) {
+	n := 0
+	for _, c := range text {
+		switch {
+		case strings.ContainsRune(gsm7Basic, c):
+			n++
+		case strings.ContainsRune(gsm7Extension, c):
+			n += 2
+		default:
+			return 0, false
+		}
+	}
+	return n, true
+}
+
+// segments 计费条数
+func segments(n, single, multi int) int {
+	if n == 0 {
+		return 0
+	}
+	if n <= single {
+		return 1
+	}
+	return (n + multi - 1) / multi
+}
+
+// CalcLengthWithContent 使用模板渲染后的内容计算短信长度及计费条数
+func (s *SendSmsRequest) CalcLengthWithContent(content string) SmsLength {
+	return CalcSmsLength(s.GetSignName(), content, s.IsInternational())
+}
+
+// CalcLength 使用短信模板注册表渲染短信内容, 计算短信长度及计费条数
+func (s *SendSmsRequest) CalcLength() (SmsLength, error) {
+	content, err := s.Render()
+	if err != nil {
+		return SmsLength{}, err
+	}
+	return s.CalcLengthWithContent(content), nil
+}
+
+// TotalSegments 使用短信模板注册表计算本次发送的总计费条数, 用于发送前的预算检查
+func (s *SendSmsRequest) TotalSegments() (int, error) {
+	l, err := s.CalcLength()
+	if err != nil {
+		return 0, err
+	}
+	recipients := 0
+	if phoneNumbers := s.GetPhoneNumbers(); phoneNumbers != "" {
+		recipients = len(strings.Split(phoneNumbers, ","))
+	}
+	return l.TotalSegments(recipients), nil
+}
@@ -0,0 +1,40 @@
+package dysms
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_CalcSmsLength(t *testing.T) {
+	tests := []struct {
+		sign          string
+		content       string
+		international bool
+		want          SmsLength
+	}{
+		{"阿里云", strings.Repeat("短", 65), false, SmsLength{70, 1, EncodingUCS2}},
+		{"阿里云", strings.Repeat("短", 66), false, SmsLength{71, 2, EncodingUCS2}},
+		{"阿里云", strings.Repeat("a", 130), false, SmsLength{135, 3, EncodingUCS2}},
+		{"", strings.Repeat("a", 160), true, SmsLength{160, 1, EncodingGSM7}},
+		{"", strings.Repeat("a", 161), true, SmsLength{161, 2, EncodingGSM7}},
+		{"", strings.Repeat("{", 80), true, SmsLength{160, 1, EncodingGSM7}},
+		{"", "Your code is 1234 😀", true, SmsLength{20, 1, EncodingUCS2}},
+		{"Alicloud", "Your code is 1234", true, SmsLength{29, 1, EncodingGSM7}},
+		{"阿里云", "Your code is 1234", true, SmsLength{22, 1, EncodingUCS2}},
+	}
+	for _, test := range tests {
+		if got := CalcSmsLength(test.sign, test.content, test.international); got != test.want {
+			t.Error("CalcSmsLength failed", test.content, got, test.want)
+		}
+	}
+}
+
+func Test_SendSmsRequestTotalSegments(t *testing.T) {
+	registry := NewTemplateRegistry()
+	registry.Register(NewTemplate("SMS_1", strings.Repeat("短", 60)+"${code}"))
+	r := SendSms("1", "1375821****,1835718****", "阿里云", "SMS_1", `{"code":"123456"}`)
+	r.SetTemplateRegistry(registry)
+	if n, err := r.TotalSegments(); err != nil || n != 4 {
+		t.Error("TotalSegments failed", n, err)
+	}
+}
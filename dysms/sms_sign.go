@@ -0,0 +1,92 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"strconv"
+)
+
+// SignSource 签名来源
+type SignSource int
+
+// 签名来源取值
+const (
+	SignSourceEnterprise      SignSource = iota // 0：企事业单位的全称或简称
+	SignSourceWebsite                           // 1：工信部备案网站的全称或简称
+	SignSourceApp                               // 2：App应用的全称或简称
+	SignSourceOfficialAccount                   // 3：公众号或小程序的全称或简称
+	SignSourceEShop                             // 4：电商平台店铺名的全称或简称
+	SignSourceTrademark                         // 5：商标名的全称或简称
+)
+
+// String 签名来源描述
+func (s SignSource) String() string {
+	switch s {
+	case SignSourceEnterprise:
+		return "企事业单位的全称或简称"
+	case SignSourceWebsite:
+		return "工信部备案网站的全称或简称"
+	case SignSourceApp:
+		return "App应用的全称或简称"
+	case SignSourceOfficialAccount:
+		return "公众号或小程序的全称或简称"
+	case SignSourceEShop:
+		return "电商平台店铺名的全称或简称"
+	case SignSourceTrademark:
+		return "商标名的全称或简称"
+	}
+	return "SignSource(" + strconv.Itoa(int(s)) + ")"
+}
+
+// SignStatus 签名审核状态, QuerySmsSign 接口返回
+type SignStatus int
+
+// 签名审核状态取值
+const (
+	SignStatusAuditing SignStatus = iota // 0：审核中
+	SignStatusApproved                   // 1：审核通过
+	SignStatusRejected                   // 2：审核失败, 请在返回参数Reason中查看审核失败原因
+)
+
+// String 签名审核状态描述
+func (s SignStatus) String() string {
+	switch s {
+	case SignStatusAuditing:
+		return "审核中"
+	case SignStatusApproved:
+		return "审核通过"
+	case SignStatusRejected:
+		return "审核失败"
+	}
+	return "SignStatus(" + strconv.Itoa(int(s)) + ")"
+}
+
+// AuditStatus 签名/模板审核状态, QuerySmsSignList 等列表接口返回
+type AuditStatus string
+
+// 审核状态取值
+const (
+	AuditStatusInit    AuditStatus = "AUDIT_STATE_INIT"     // 审核中
+	AuditStatusPass    AuditStatus = "AUDIT_STATE_PASS"     // 审核通过
+	AuditStatusNotPass AuditStatus = "AUDIT_STATE_NOT_PASS" // 审核未通过
+	AuditStatusCancel  AuditStatus = "AUDIT_STATE_CANCEL"   // 取消审核
+)
+
+// Approved 是否审核通过
+func (a AuditStatus) Approved() bool {
+	return a == AuditStatusPass
+}
+
+// SignFile 签名资质证明文件
+type SignFile struct {
+	FileContents string // 签名的资质证明文件经base64编码后的字符串, 图片不超过2 MB
+	FileSuffix   string // 签名的证明文件格式, 支持jpg、png、gif或jpeg
+}
+
+// putSignFileList 将资质证明文件列表编码为 SignFileList.N.FileContents/SignFileList.N.FileSuffix 参数
+func putSignFileList(r *Request, signFileList []SignFile) {
+	for i, f := range signFileList {
+		n := strconv.Itoa(i + 1)
+		r.Put("SignFileList."+n+".FileContents", f.FileContents)
+		r.Put("SignFileList."+n+".FileSuffix", f.FileSuffix)
+	}
+}
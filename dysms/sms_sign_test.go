@@ -0,0 +1,40 @@
+package dysms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_AddSmsSign(t *testing.T) {
+	r := AddSmsSign("测试签名", SignSourceApp, "app", SignFile{FileContents: "YWJj", FileSuffix: "jpg"}, SignFile{FileContents: "ZGVm", FileSuffix: "png"})
+	if r.Request.Get("Action") != "AddSmsSign" || r.Request.Get("SignSource") != "2" {
+		t.Error("AddSmsSign params failed")
+	}
+	if r.Request.Get("SignFileList.1.FileContents") != "YWJj" || r.Request.Get("SignFileList.2.FileSuffix") != "png" {
+		t.Error("AddSmsSign SignFileList failed")
+	}
+	if r.GetSignSource() != SignSourceApp {
+		t.Error("GetSignSource failed")
+	}
+}
+
+func Test_QuerySmsSign(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("Action") != "QuerySmsSign" || r.URL.Query().Get("Signature") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"RequestId":"r1","Code":"OK","Message":"OK","SignName":"测试签名","SignStatus":1,"Reason":"","CreateDate":"2019-01-08 16:44:13"}`))
+	}))
+	defer ts.Close()
+	defer setTestEndPoint(ts.URL)()
+
+	resp, err := QuerySmsSign("测试签名").DoActionWithException()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetSignStatus() != SignStatusApproved || !resp.Approved() || resp.GetSignName() != "测试签名" {
+		t.Error("QuerySmsSign response failed", resp.String())
+	}
+}
@@ -0,0 +1,55 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"strconv"
+)
+
+// TemplateType 短信模板类型
+type TemplateType int
+
+// 短信模板类型取值
+const (
+	TemplateTypeVerification  TemplateType = iota // 0：验证码
+	TemplateTypeNotification                      // 1：短信通知
+	TemplateTypePromotion                         // 2：推广短信
+	TemplateTypeInternational                     // 3：国际/港澳台消息
+)
+
+// String 短信模板类型描述
+func (t TemplateType) String() string {
+	switch t {
+	case TemplateTypeVerification:
+		return "验证码"
+	case TemplateTypeNotification:
+		return "短信通知"
+	case TemplateTypePromotion:
+		return "推广短信"
+	case TemplateTypeInternational:
+		return "国际/港澳台消息"
+	}
+	return "TemplateType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// TemplateStatus 短信模板审核状态
+type TemplateStatus int
+
+// 短信模板审核状态取值
+const (
+	TemplateStatusAuditing TemplateStatus = iota // 0：审核中
+	TemplateStatusApproved                       // 1：审核通过
+	TemplateStatusRejected                       // 2：审核失败
+)
+
+// String 短信模板审核状态描述
+func (t TemplateStatus) String() string {
+	switch t {
+	case TemplateStatusAuditing:
+		return "审核中"
+	case TemplateStatusApproved:
+		return "审核通过"
+	case TemplateStatusRejected:
+		return "审核失败"
+	}
+	return "TemplateStatus(" + strconv.Itoa(int(t)) + ")"
+}
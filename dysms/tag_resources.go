@@ -0,0 +1,49 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"sort"
+	"strconv"
+)
+
+// ResourceTypeTemplate 资源类型: 短信模板
+const ResourceTypeTemplate = "TEMPLATE"
+
+// tagProdCode 标签接口的产品代码
+const tagProdCode = "dysms"
+
+// TagResource 资源标签
+type TagResource struct {
+	ResourceType string `json:"ResourceType"` // 资源类型
+	ResourceID   string `json:"ResourceId"`   // 资源ID, 即短信模板CODE
+	TagKey       string `json:"TagKey"`       // 标签键
+	TagValue     string `json:"TagValue"`     // 标签值
+}
+
+// putResourceIDs 将资源ID列表编码为 ResourceId.N 参数
+func putResourceIDs(r *Request, resourceIDs []string) {
+	for i, id := range resourceIDs {
+		r.Put("ResourceId."+strconv.Itoa(i+1), id)
+	}
+}
+
+// putTags 将标签编码为 Tag.N.Key/Tag.N.Value 参数, 按标签键排序以保证编码顺序稳定
+func putTags(r *Request, tags map[string]string) {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		n := strconv.Itoa(i + 1)
+		r.Put("Tag."+n+".Key", k)
+		r.Put("Tag."+n+".Value", tags[k])
+	}
+}
+
+// putTagKeys 将标签键列表编码为 TagKey.N 参数
+func putTagKeys(r *Request, tagKeys []string) {
+	for i, k := range tagKeys {
+		r.Put("TagKey."+strconv.Itoa(i+1), k)
+	}
+}
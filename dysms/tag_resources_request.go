@@ -0,0 +1,101 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// TagResourcesResponse 为资源添加标签接口服务器响应
+type TagResourcesResponse struct {
+	ErrorMessage
+	Data *string `json:"Data,omitempty"` // 添加结果, true表示成功
+}
+
+// GetData 获取添加结果
+func (t *TagResourcesResponse) GetData() string {
+	if t != nil && t.Data != nil {
+		return *t.Data
+	}
+	return ""
+}
+
+// String 序列化成JSON字符串
+func (t TagResourcesResponse) String() string {
+	body, err := json.Marshal(t)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// TagResourcesRequest 为资源添加标签接口请求
+type TagResourcesRequest struct {
+	Request *Request
+}
+
+// SetResourceType 设置资源类型 必须, 目前仅支持 ResourceTypeTemplate
+func (t *TagResourcesRequest) SetResourceType(resourceType string) {
+	if t != nil && t.Request != nil {
+		t.Request.Put("ResourceType", resourceType)
+	}
+}
+
+// GetResourceType 获取资源类型
+func (t *TagResourcesRequest) GetResourceType() string {
+	if t != nil && t.Request != nil {
+		return t.Request.Get("ResourceType")
+	}
+	return ""
+}
+
+// SetResourceIDs 设置资源ID列表 必须, 即短信模板CODE
+func (t *TagResourcesRequest) SetResourceIDs(resourceIDs []string) {
+	if t != nil && t.Request != nil {
+		putResourceIDs(t.Request, resourceIDs)
+	}
+}
+
+// SetTags 设置标签 必须
+func (t *TagResourcesRequest) SetTags(tags map[string]string) {
+	if t != nil && t.Request != nil {
+		putTags(t.Request, tags)
+	}
+}
+
+// DoActionWithException 发起HTTP请求
+func (t *TagResourcesRequest) DoActionWithException() (resp *TagResourcesResponse, err error) {
+	if t != nil && t.Request != nil {
+		resp := &TagResourcesResponse{}
+		body, httpCode, err := t.Request.Do("TagResources")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("TagResourcesRequest is nil")
+}
+
+// TagResources 为短信模板添加标签接口
+// resourceIDs 必填 - 短信模板CODE列表
+// tags 必填 - 标签键值对
+func TagResources(resourceIDs []string, tags map[string]string) *TagResourcesRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "TagResources")
+	req.Put("ProdCode", tagProdCode)
+
+	r := &TagResourcesRequest{Request: req}
+	r.SetResourceType(ResourceTypeTemplate) // 必填 - 资源类型
+	r.SetResourceIDs(resourceIDs)           // 必填 - 短信模板CODE列表
+	r.SetTags(tags)                         // 必填 - 标签键值对
+	return r
+}
@@ -0,0 +1,40 @@
+package dysms
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_TagResources(t *testing.T) {
+	r := TagResources([]string{"SMS_1", "SMS_2"}, map[string]string{"cost-center": "ops", "app": "otp"})
+	if r.Request.Get("ResourceId.2") != "SMS_2" || r.GetResourceType() != ResourceTypeTemplate {
+		t.Error("TagResources ResourceId failed")
+	}
+	if r.Request.Get("Tag.1.Key") != "app" || r.Request.Get("Tag.1.Value") != "otp" || r.Request.Get("Tag.2.Key") != "cost-center" {
+		t.Error("TagResources Tag failed")
+	}
+}
+
+func Test_ListTagResourcesPaginator(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("NextToken") {
+		case "":
+			w.Write([]byte(`{"RequestId":"r1","Code":"OK","NextToken":"t2","TagResources":{"TagResource":[{"ResourceType":"TEMPLATE","ResourceId":"SMS_1","TagKey":"k","TagValue":"v"}]}}`))
+		case "t2":
+			w.Write([]byte(`{"RequestId":"r2","Code":"OK","TagResources":{"TagResource":[{"ResourceType":"TEMPLATE","ResourceId":"SMS_2","TagKey":"k","TagValue":"v"}]}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+	defer setTestEndPoint(ts.URL)()
+
+	tags, err := NewListTagResourcesPaginator(ListTagResources(nil, map[string]string{"k": "v"})).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 2 || tags[0].ResourceID != "SMS_1" || tags[1].ResourceID != "SMS_2" {
+		t.Error("ListTagResourcesPaginator failed", tags)
+	}
+}
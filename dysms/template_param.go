@@ -0,0 +1,50 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// MarshalTemplateParam 将 map 或带 json 标签的结构体序列化为短信模板变量替换JSON串
+// 序列化时不转义HTML字符, 链接中的&等字符将原样保留; 模板变量的值必须为字符串, 否则返回出错的变量名
+func MarshalTemplateParam(v interface{}) (string, error) {
+	if v == nil {
+		return "", errors.New("template param is nil")
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return "", err
+	}
+
+	var values map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(buf.Bytes()))
+	dec.UseNumber()
+	if err := dec.Decode(&values); err != nil || values == nil {
+		return "", errors.New("template param should be a map or struct")
+	}
+	for k, value := range values {
+		if _, ok := value.(string); !ok {
+			return "", fmt.Errorf("template param %q should be a string", k)
+		}
+	}
+	return string(bytes.TrimRight(buf.Bytes(), "\n")), nil
+}
+
+// SetTemplateParamObject 使用 map 或带 json 标签的结构体设置短信模板变量
+// 如: map[string]string{"code": "1234"} 或 struct{ Code string `json:"code"` }{"1234"}
+func (s *SendSmsRequest) SetTemplateParamObject(v interface{}) error {
+	if s == nil || s.Request == nil {
+		return errors.New("SendSmsRequest is nil")
+	}
+	templateParam, err := MarshalTemplateParam(v)
+	if err != nil {
+		return err
+	}
+	s.SetTemplateParam(templateParam)
+	return nil
+}
@@ -0,0 +1,36 @@
+package dysms
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_MarshalTemplateParam(t *testing.T) {
+	type offline struct {
+		DeviceID   string `json:"device_id"`
+		DeviceName string `json:"devicename"`
+		URL        string `json:"url"`
+	}
+	s, err := MarshalTemplateParam(offline{"T0000001", "测试设备", "https://example.com/?a=1&b=<2>"})
+	if err != nil || s != `{"device_id":"T0000001","devicename":"测试设备","url":"https://example.com/?a=1&b=<2>"}` {
+		t.Error("MarshalTemplateParam struct failed", s, err)
+	}
+
+	s, err = MarshalTemplateParam(map[string]string{"code": "1234"})
+	if err != nil || s != `{"code":"1234"}` {
+		t.Error("MarshalTemplateParam map failed", s, err)
+	}
+
+	_, err = MarshalTemplateParam(map[string]interface{}{"code": "1234", "count": 3})
+	if err == nil || !strings.Contains(err.Error(), `"count"`) {
+		t.Error("MarshalTemplateParam should reject non-string value", err)
+	}
+	if _, err = MarshalTemplateParam([]string{"a"}); err == nil {
+		t.Error("MarshalTemplateParam should reject array")
+	}
+
+	r := SendSms("1", "1375821****", "测试签名", "SMS_1", "")
+	if err = r.SetTemplateParamObject(map[string]string{"url": "a&b"}); err != nil || r.GetTemplateParam() != `{"url":"a&b"}` {
+		t.Error("SetTemplateParamObject failed", r.GetTemplateParam(), err)
+	}
+}
@@ -0,0 +1,306 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// DefaultMaxParamLength 模板变量值的默认长度上限, 按字符计算
+const DefaultMaxParamLength = 35
+
+// DefaultTemplateRegistry 默认的短信模板注册表, 不为nil时发送短信前会使用它校验模板变量
+var DefaultTemplateRegistry *TemplateRegistry
+
+// templateVariable 匹配模板内容中的 ${var} 变量
+var templateVariable = regexp.MustCompile(`\$\{([^${}]+)\}`)
+
+// Template 短信模板
+type Template struct {
+	Code           string       `json:"code"`                       // 短信模板CODE
+	Content        string       `json:"content"`                    // 模板内容, 如: 尊敬的用户，您的${device_id}(${devicename})设备已离线
+	Type           TemplateType `json:"type,omitempty"`             // 短信类型
+	MaxParamLength int          `json:"max_param_length,omitempty"` // 模板变量值的长度上限, 为0时使用 DefaultMaxParamLength
+
+	variables []string
+}
+
+// NewTemplate 创建一个短信模板, 并解析模板内容中的变量
+func NewTemplate(code, content string) *Template {
+	t := &Template{Code: code, Content: content}
+	t.parse()
+	return t
+}
+
+// parse 按出现顺序解析模板内容中的变量
+func (t *Template) parse() {
+	t.variables = nil
+	seen := make(map[string]bool)
+	for _, m := range templateVariable.FindAllStringSubmatch(t.Content, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			t.variables = append(t.variables, m[1])
+		}
+	}
+}
+
+// Variables 模板内容中的变量, 按出现顺序排列
+func (t *Template) Variables() []string {
+	return append([]string(nil), t.variables...)
+}
+
+// maxParamLength 模板变量值的长度上限
+func (t *Template) maxParamLength() int {
+	if t.MaxParamLength > 0 {
+		return t.MaxParamLength
+	}
+	return DefaultMaxParamLength
+}
+
+// Validate 校验模板变量, 返回缺少、多余和超长的变量
+func (t *Template) Validate(params map[string]string) error {
+	e := &TemplateParamError{TemplateCode: t.Code}
+	known := make(map[string]bool, len(t.variables))
+	for _, v := range t.variables {
+		known[v] = true
+		value, ok := params[v]
+		if !ok {
+			e.Missing = append(e.Missing, v)
+			continue
+		}
+		if utf8.RuneCountInString(value) > t.maxParamLength() {
+			e.TooLong = append(e.TooLong, v)
+		}
+	}
+	for k := range params {
+		if !known[k] {
+			e.Extra = append(e.Extra, k)
+		}
+	}
+	sort.Strings(e.Extra)
+	if len(e.Missing) > 0 || len(e.Extra) > 0 || len(e.TooLong) > 0 {
+		return e
+	}
+	return nil
+}
+
+// Render 使用模板变量渲染最终的短信内容, 用于预览和日志
+func (t *Template) Render(params map[string]string) (string, error) {
+	if err := t.Validate(params); err != nil {
+		return "", err
+	}
+	return templateVariable.ReplaceAllStringFunc(t.Content, func(s string) string {
+		return params[s[2:len(s)-1]]
+	}), nil
+}
+
+// TemplateParamError 模板变量校验失败
+type TemplateParamError struct {
+	TemplateCode string   // 短信模板CODE
+	Missing      []string // 缺少的变量
+	Extra        []string // 模板中不存在的变量
+	TooLong      []string // 值超过长度上限的变量
+}
+
+// Error 错误描述
+func (e *TemplateParamError) Error() string {
+	var msgs []string
+	if len(e.Missing) > 0 {
+		msgs = append(msgs, "missing "+strings.Join(e.Missing, ","))
+	}
+	if len(e.Extra) > 0 {
+		msgs = append(msgs, "extra "+strings.Join(e.Extra, ","))
+	}
+	if len(e.TooLong) > 0 {
+		msgs = append(msgs, "too long "+strings.Join(e.TooLong, ","))
+	}
+	return "template " + e.TemplateCode + " params: " + strings.Join(msgs, "; ")
+}
+
+// TemplateRegistry 本地短信模板注册表, 可安全地并发使用
+type TemplateRegistry struct {
+	mu        sync.RWMutex
+	templates map[string]*Template
+}
+
+// NewTemplateRegistry 创建一个短信模板注册表
+func NewTemplateRegistry() *TemplateRegistry {
+	return &TemplateRegistry{templates: make(map[string]*Template)}
+}
+
+// Register 注册一个短信模板的副本, 已存在的同名模板将被替换
+func (r *TemplateRegistry) Register(t *Template) {
+	c := *t
+	c.parse()
+	r.mu.Lock()
+	if r.templates == nil {
+		r.templates = make(map[string]*Template)
+	}
+	r.templates[c.Code] = &c
+	r.mu.Unlock()
+}
+
+// Get 获取已注册的短信模板
+func (r *TemplateRegistry) Get(templateCode string) (*Template, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.templates[templateCode]
+	return t, ok
+}
+
+// LoadFile 从JSON文件中加载短信模板列表, YAML文件见 dysms/templateyaml
+func (r *TemplateRegistry) LoadFile(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return r.LoadJSON(data)
+}
+
+// Load 从JSON数组中加载短信模板列表
+func (r *TemplateRegistry) Load(reader io.Reader) error {
+	var templates []*Template
+	if err := json.NewDecoder(reader).Decode(&templates); err != nil {
+		return err
+	}
+	return r.RegisterAll(templates)
+}
+
+// LoadJSON 从JSON数组中加载短信模板列表, 如: [{"code":"SMS_1","content":"您的验证码为${code}"}]
+func (r *TemplateRegistry) LoadJSON(data []byte) error {
+	var templates []*Template
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return err
+	}
+	return r.RegisterAll(templates)
+}
+
+// RegisterAll 注册多个短信模板, 存在没有CODE的模板时不注册任何模板
+func (r *TemplateRegistry) RegisterAll(templates []*Template) error {
+	for i, t := range templates {
+		if t == nil || t.Code == "" {
+			return fmt.Errorf("template #%d has no code", i)
+		}
+	}
+	for _, t := range templates {
+		r.Register(t)
+	}
+	return nil
+}
+
+// Sync 通过 QuerySmsTemplate 接口同步短信模板的内容和类型
+func (r *TemplateRegistry) Sync(templateCodes ...string) error {
+	for _, code := range templateCodes {
+		resp, err := QuerySmsTemplate(code).DoActionWithException()
+		if err != nil {
+			return err
+		}
+		if resp.TemplateContent == nil {
+			return &resp.ErrorMessage
+		}
+		t := NewTemplate(code, resp.GetTemplateContent())
+		t.Type = resp.GetTemplateType()
+		if old, ok := r.Get(code); ok {
+			t.MaxParamLength = old.MaxParamLength
+		}
+		r.Register(t)
+	}
+	return nil
+}
+
+// Validate 校验短信模板变量, 未注册的模板不做校验
+func (r *TemplateRegistry) Validate(templateCode string, params map[string]string) error {
+	t, ok := r.Get(templateCode)
+	if !ok {
+		return nil
+	}
+	return t.Validate(params)
+}
+
+// Render 渲染最终的短信内容
+func (r *TemplateRegistry) Render(templateCode string, params map[string]string) (string, error) {
+	t, ok := r.Get(templateCode)
+	if !ok {
+		return "", errors.New("template not registered: " + templateCode)
+	}
+	return t.Render(params)
+}
+
+// parseTemplateParam 将短信模板变量替换JSON串解析为键值对
+func parseTemplateParam(templateParam string) (map[string]string, error) {
+	params := make(map[string]string)
+	if templateParam == "" {
+		return params, nil
+	}
+	var values map[string]interface{}
+	dec := json.NewDecoder(strings.NewReader(templateParam))
+	dec.UseNumber()
+	if err := dec.Decode(&values); err != nil {
+		return nil, err
+	}
+	for k, v := range values {
+		params[k] = fmt.Sprint(v)
+	}
+	return params, nil
+}
+
+// SetTemplateRegistry 设置发送前用于校验模板变量的短信模板注册表, 为nil时使用 DefaultTemplateRegistry
+func (s *SendSmsRequest) SetTemplateRegistry(registry *TemplateRegistry) {
+	if s != nil {
+		s.registry = registry
+	}
+}
+
+// templateRegistry 发送前用于校验模板变量的短信模板注册表
+func (s *SendSmsRequest) templateRegistry() *TemplateRegistry {
+	if s.registry != nil {
+		return s.registry
+	}
+	return DefaultTemplateRegistry
+}
+
+// GetTemplateParamMap 获取短信模板变量键值对
+func (s *SendSmsRequest) GetTemplateParamMap() (map[string]string, error) {
+	return parseTemplateParam(s.GetTemplateParam())
+}
+
+// ValidateTemplateParam 使用短信模板注册表校验模板变量, 模板未注册时不做校验
+func (s *SendSmsRequest) ValidateTemplateParam() error {
+	registry := s.templateRegistry()
+	if registry == nil {
+		return nil
+	}
+	t, ok := registry.Get(s.GetTemplateCode())
+	if !ok {
+		return nil
+	}
+	params, err := s.GetTemplateParamMap()
+	if err != nil {
+		return err
+	}
+	return t.Validate(params)
+}
+
+// Render 使用短信模板注册表渲染最终的短信内容(不含签名)
+func (s *SendSmsRequest) Render() (string, error) {
+	registry := s.templateRegistry()
+	if registry == nil {
+		return "", errors.New("template registry is nil")
+	}
+	params, err := s.GetTemplateParamMap()
+	if err != nil {
+		return "", err
+	}
+	return registry.Render(s.GetTemplateCode(), params)
+}
@@ -0,0 +1,67 @@
+package dysms
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Template(t *testing.T) {
+	tpl := NewTemplate("SMS_22120102", "尊敬的用户，您的${device_id}(${devicename})设备已离线, ${device_id}")
+	if !reflect.DeepEqual(tpl.Variables(), []string{"device_id", "devicename"}) {
+		t.Error("Template parse failed", tpl.Variables())
+	}
+	s, err := tpl.Render(map[string]string{"device_id": "T0000001", "devicename": "测试设备"})
+	if err != nil || s != "尊敬的用户，您的T0000001(测试设备)设备已离线, T0000001" {
+		t.Error("Template render failed", s, err)
+	}
+
+	err = tpl.Validate(map[string]string{"device_id": strings.Repeat("长", 36), "device": "T0000001"})
+	e, ok := err.(*TemplateParamError)
+	if !ok || !reflect.DeepEqual(e.Missing, []string{"devicename"}) || !reflect.DeepEqual(e.Extra, []string{"device"}) || !reflect.DeepEqual(e.TooLong, []string{"device_id"}) {
+		t.Error("Template validate failed", err)
+	}
+}
+
+func Test_TemplateRegistry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dysms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	jsonFile := filepath.Join(dir, "templates.json")
+	ioutil.WriteFile(jsonFile, []byte(`[{"code":"SMS_2","content":"您的${device_id}设备已离线"}]`), 0644)
+
+	registry := NewTemplateRegistry()
+	if err = registry.Load(strings.NewReader(`[{"code":"SMS_1","content":"您的验证码为${code}","max_param_length":6}]`)); err != nil {
+		t.Fatal(err)
+	}
+	if err = registry.LoadFile(jsonFile); err != nil {
+		t.Fatal(err)
+	}
+	if err = registry.Load(strings.NewReader(`[{"content":"no code"}]`)); err == nil {
+		t.Error("TemplateRegistry should reject template without code")
+	}
+
+	// 注册的是副本, 修改原模板不影响注册表
+	tpl := NewTemplate("SMS_3", "${a}")
+	registry.Register(tpl)
+	tpl.Content = "${b}"
+	if got, _ := registry.Get("SMS_3"); got == tpl || !reflect.DeepEqual(got.Variables(), []string{"a"}) {
+		t.Error("TemplateRegistry register should copy the template", got)
+	}
+
+	r := SendSms("1", "1375821****", "测试签名", "SMS_1", `{"code":"1234567"}`)
+	r.SetTemplateRegistry(registry)
+	if _, err = r.DoActionWithException(); err == nil || !strings.Contains(err.Error(), "too long code") {
+		t.Error("SendSms should validate template param before sending", err)
+	}
+	r = SendSms("1", "1375821****", "测试签名", "SMS_2", `{"device_id":"T0000001"}`)
+	r.SetTemplateRegistry(registry)
+	if s, err := r.Render(); err != nil || s != "您的T0000001设备已离线" {
+		t.Error("SendSmsRequest render failed", s, err)
+	}
+}
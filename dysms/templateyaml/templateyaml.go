@@ -0,0 +1,43 @@
+// Package templateyaml Copyright 2016 The GiterLab Authors. All rights reserved.
+//
+// templateyaml 从YAML文件中加载短信模板到 dysms.TemplateRegistry, 只有使用YAML格式时才需要依赖 gopkg.in/yaml.v2
+package templateyaml
+
+import (
+	"io/ioutil"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// template YAML中的短信模板, 字段同 dysms.Template
+type template struct {
+	Code           string             `yaml:"code"`                       // 短信模板CODE
+	Content        string             `yaml:"content"`                    // 模板内容
+	Type           dysms.TemplateType `yaml:"type,omitempty"`             // 短信类型
+	MaxParamLength int                `yaml:"max_param_length,omitempty"` // 模板变量值的长度上限
+}
+
+// Load 从YAML列表中加载短信模板列表, 字段名同JSON格式: code, content, type, max_param_length
+func Load(r *dysms.TemplateRegistry, data []byte) error {
+	var list []*template
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	templates := make([]*dysms.Template, len(list))
+	for i, t := range list {
+		if t != nil {
+			templates[i] = &dysms.Template{Code: t.Code, Content: t.Content, Type: t.Type, MaxParamLength: t.MaxParamLength}
+		}
+	}
+	return r.RegisterAll(templates)
+}
+
+// LoadFile 从YAML文件中加载短信模板列表
+func LoadFile(r *dysms.TemplateRegistry, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return Load(r, data)
+}
@@ -0,0 +1,32 @@
+package templateyaml
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+)
+
+func Test_LoadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "templateyaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	yamlFile := filepath.Join(dir, "templates.yaml")
+	ioutil.WriteFile(yamlFile, []byte("- code: SMS_1\n  content: 您的验证码为${code}\n  max_param_length: 6\n"), 0644)
+
+	registry := dysms.NewTemplateRegistry()
+	if err = LoadFile(registry, yamlFile); err != nil {
+		t.Fatal(err)
+	}
+	if err = registry.Validate("SMS_1", map[string]string{"code": "1234567"}); err == nil || !strings.Contains(err.Error(), "too long code") {
+		t.Error("LoadFile failed", err)
+	}
+	if err = Load(registry, []byte("- content: no code\n")); err == nil {
+		t.Error("Load should reject template without code")
+	}
+}
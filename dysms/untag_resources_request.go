@@ -0,0 +1,113 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// UntagResourcesResponse 删除资源标签接口服务器响应
+type UntagResourcesResponse struct {
+	ErrorMessage
+	Data *string `json:"Data,omitempty"` // 删除结果, true表示成功
+}
+
+// GetData 获取删除结果
+func (u *UntagResourcesResponse) GetData() string {
+	if u != nil && u.Data != nil {
+		return *u.Data
+	}
+	return ""
+}
+
+// String 序列化成JSON字符串
+func (u UntagResourcesResponse) String() string {
+	body, err := json.Marshal(u)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+// UntagResourcesRequest 删除资源标签接口请求
+type UntagResourcesRequest struct {
+	Request *Request
+}
+
+// SetResourceType 设置资源类型 必须, 目前仅支持 ResourceTypeTemplate
+func (u *UntagResourcesRequest) SetResourceType(resourceType string) {
+	if u != nil && u.Request != nil {
+		u.Request.Put("ResourceType", resourceType)
+	}
+}
+
+// GetResourceType 获取资源类型
+func (u *UntagResourcesRequest) GetResourceType() string {
+	if u != nil && u.Request != nil {
+		return u.Request.Get("ResourceType")
+	}
+	return ""
+}
+
+// SetResourceIDs 设置资源ID列表 必须, 即短信模板CODE
+func (u *UntagResourcesRequest) SetResourceIDs(resourceIDs []string) {
+	if u != nil && u.Request != nil {
+		putResourceIDs(u.Request, resourceIDs)
+	}
+}
+
+// SetTagKeys 设置要删除的标签键列表
+func (u *UntagResourcesRequest) SetTagKeys(tagKeys []string) {
+	if u != nil && u.Request != nil {
+		putTagKeys(u.Request, tagKeys)
+	}
+}
+
+// SetAll 设置是否删除资源上的全部标签, 未指定标签键列表时有效
+func (u *UntagResourcesRequest) SetAll(all bool) {
+	if u != nil && u.Request != nil {
+		u.Request.Put("All", strconv.FormatBool(all))
+	}
+}
+
+// DoActionWithException 发起HTTP请求
+func (u *UntagResourcesRequest) DoActionWithException() (resp *UntagResourcesResponse, err error) {
+	if u != nil && u.Request != nil {
+		resp := &UntagResourcesResponse{}
+		body, httpCode, err := u.Request.Do("UntagResources")
+		resp.SetHTTPCode(httpCode)
+		if err != nil {
+			return resp, err
+		}
+		err = json.Unmarshal(body, resp)
+		if err != nil {
+			return resp, err
+		}
+		if httpCode != 200 {
+			return resp, errors.New(resp.GetCode())
+		}
+		return resp, nil
+	}
+	return nil, errors.New("UntagResourcesRequest is nil")
+}
+
+// UntagResources 删除短信模板标签接口
+// resourceIDs 必填 - 短信模板CODE列表
+// tagKeys 要删除的标签键列表, 为空时删除全部标签
+func UntagResources(resourceIDs []string, tagKeys []string) *UntagResourcesRequest {
+	req := newRequset()
+	req.Put("Version", "2017-05-25")
+	req.Put("Action", "UntagResources")
+	req.Put("ProdCode", tagProdCode)
+
+	r := &UntagResourcesRequest{Request: req}
+	r.SetResourceType(ResourceTypeTemplate) // 必填 - 资源类型
+	r.SetResourceIDs(resourceIDs)           // 必填 - 短信模板CODE列表
+	if len(tagKeys) > 0 {
+		r.SetTagKeys(tagKeys) // 要删除的标签键列表
+	} else {
+		r.SetAll(true) // 删除全部标签
+	}
+	return r
+}
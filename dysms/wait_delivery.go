@@ -0,0 +1,71 @@
+// Package dysms Copyright 2016 The GiterLab Authors. All rights reserved.
+package dysms
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// 等待短信回执时的轮询间隔, 没有新的回执时间隔逐步加倍, 收到新的回执后恢复为初始间隔
+var (
+	DeliveryPollInitialInterval = time.Second
+	DeliveryPollMaxInterval     = 30 * time.Second
+)
+
+// WaitForDelivery 轮询 QuerySendDetails 直到每个号码的短信都进入最终状态(发送成功或发送失败), 或 ctx 结束
+// bizID 发送回执ID
+// phoneNumbers 短信接收号码, 多个号码使用,分割
+// sendDate 发送日期
+// 返回每个号码的发送记录, 可通过 ErrCode 获取运营商错误码; ctx 结束时返回已获取的记录和 ctx.Err()
+func WaitForDelivery(ctx context.Context, bizID, phoneNumbers string, sendDate time.Time) (map[string]SmsSendDetailDTO, error) {
+	date, err := formatSendDate(sendDate)
+	if err != nil {
+		return nil, err
+	}
+	results := make(map[string]SmsSendDetailDTO)
+	pending := make(map[string]bool)
+	for _, p := range strings.Split(phoneNumbers, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			pending[p] = true
+		}
+	}
+
+	interval := DeliveryPollInitialInterval
+	for {
+		progress := false
+		for p := range pending {
+			it := NewSendDetailsIterator(ctx, QuerySendDetails(bizID, p, "", "1", date))
+			details, err := it.All()
+			if err != nil {
+				return results, err
+			}
+			for _, d := range details {
+				old, ok := results[p]
+				if !ok || old.SendStatus != d.SendStatus {
+					progress = true
+				}
+				results[p] = d
+				if d.GetSendStatus().Final() {
+					delete(pending, p)
+				}
+			}
+		}
+		if len(pending) == 0 {
+			return results, nil
+		}
+
+		if progress {
+			interval = DeliveryPollInitialInterval
+		} else if interval *= 2; interval > DeliveryPollMaxInterval {
+			interval = DeliveryPollMaxInterval
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return results, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
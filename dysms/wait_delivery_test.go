@@ -0,0 +1,49 @@
+package dysms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_WaitForDelivery(t *testing.T) {
+	defer func(initial, max time.Duration) {
+		DeliveryPollInitialInterval, DeliveryPollMaxInterval = initial, max
+	}(DeliveryPollInitialInterval, DeliveryPollMaxInterval)
+	DeliveryPollInitialInterval, DeliveryPollMaxInterval = time.Millisecond, 4*time.Millisecond
+
+	polls := make(map[string]int)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		phone := r.URL.Query().Get("PhoneNumber")
+		polls[phone]++
+		status, errCode := 1, ""
+		switch {
+		case phone == "13800000000" && polls[phone] >= 3:
+			status, errCode = 3, "DELIVERED"
+		case phone == "13800000001" && polls[phone] >= 2:
+			status, errCode = 2, "MK:0001"
+		}
+		fmt.Fprintf(w, `{"Code":"OK","TotalCount":1,"SmsSendDetailDTOs":{"SmsSendDetailDTO":[{"PhoneNum":"%s","SendStatus":%d,"ErrCode":"%s"}]}}`, phone, status, errCode)
+	}))
+	defer ts.Close()
+	defer setTestEndPoint(ts.URL + "/")()
+
+	results, err := WaitForDelivery(context.Background(), "biz", "13800000000,13800000001", time.Now())
+	if err != nil || results["13800000000"].ErrCode != "DELIVERED" || results["13800000001"].GetSendStatus() != SendStatusFailed {
+		t.Error("WaitForDelivery failed", results, err)
+	}
+	if polls["13800000001"] != 2 {
+		t.Error("WaitForDelivery should stop polling final numbers", polls)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	polls = make(map[string]int)
+	results, err = WaitForDelivery(ctx, "biz", "13900000000", time.Now())
+	if err != context.DeadlineExceeded || results["13900000000"].GetSendStatus() != SendStatusWaiting {
+		t.Error("WaitForDelivery timeout failed", results, err)
+	}
+}
@@ -0,0 +1,62 @@
+// Package phone Copyright 2016 The GiterLab Authors. All rights reserved.
+package phone
+
+import (
+	"strings"
+)
+
+// Rejection 未通过校验的号码及原因
+type Rejection struct {
+	Input  string // 原始输入
+	Reason error  // 原因
+}
+
+// ListError 号码列表中未通过校验的号码
+type ListError []Rejection
+
+// Error 错误描述
+func (e ListError) Error() string {
+	msgs := make([]string, len(e))
+	for i, r := range e {
+		msgs[i] = r.Input + ": " + r.Reason.Error()
+	}
+	return "invalid phone numbers: " + strings.Join(msgs, "; ")
+}
+
+// ParseList 规范化、校验并去重号码列表, 返回有效号码及未通过校验的号码, 有效号码保持输入顺序
+func ParseList(inputs []string) ([]Number, ListError) {
+	var numbers []Number
+	var rejected ListError
+	seen := make(map[string]bool, len(inputs))
+	for _, s := range inputs {
+		n, err := Parse(s)
+		if err != nil {
+			rejected = append(rejected, Rejection{Input: s, Reason: err})
+			continue
+		}
+		if seen[n.E164()] {
+			continue
+		}
+		seen[n.E164()] = true
+		numbers = append(numbers, n)
+	}
+	return numbers, rejected
+}
+
+// Dedupe 规范化并去重号码列表, 未通过校验的号码按原样保留一次
+func Dedupe(inputs []string) []string {
+	var result []string
+	seen := make(map[string]bool, len(inputs))
+	for _, s := range inputs {
+		key := s
+		if n, err := Parse(s); err == nil {
+			key = n.String()
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, key)
+	}
+	return result
+}
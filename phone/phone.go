@@ -0,0 +1,131 @@
+// Package phone Copyright 2016 The GiterLab Authors. All rights reserved.
+//
+// phone 实现短信接收号码的规范化、校验和去重
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+// 号码校验错误
+var (
+	ErrEmpty         = errors.New("phone number is empty")
+	ErrInvalidChar   = errors.New("phone number contains invalid characters")
+	ErrInvalidLength = errors.New("phone number has invalid length")
+	ErrUnknownPrefix = errors.New("phone number has unknown prefix")
+)
+
+// Carrier 运营商
+type Carrier string
+
+// 运营商取值
+const (
+	CarrierUnknown       Carrier = ""
+	CarrierChinaMobile   Carrier = "ChinaMobile"   // 中国移动
+	CarrierChinaUnicom   Carrier = "ChinaUnicom"   // 中国联通
+	CarrierChinaTelecom  Carrier = "ChinaTelecom"  // 中国电信
+	CarrierChinaBroadnet Carrier = "ChinaBroadnet" // 中国广电
+)
+
+// Number 规范化后的号码
+type Number struct {
+	Input         string  // 原始输入
+	National      string  // 国内号码为11位手机号, 国际号码为国际区号+号码
+	International bool    // 是否为国际/港澳台号码
+	Carrier       Carrier // 国内号码所属运营商
+	Virtual       bool    // 是否为虚拟运营商号段
+}
+
+// E164 E.164格式的号码, 如+8613800138000
+func (n Number) E164() string {
+	if n.International {
+		return "+" + n.National
+	}
+	return "+86" + n.National
+}
+
+// String 国内号码返回11位手机号, 国际号码返回E.164格式
+func (n Number) String() string {
+	if n.International {
+		return n.E164()
+	}
+	return n.National
+}
+
+// Normalize 规范化号码: 全角字符转半角, 去除空格、横线、括号等分隔符, 去除+86/0086国家码
+// 国际号码以+开头, 以00开头的号码会被转换为+开头
+func Normalize(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch {
+		case c >= '０' && c <= '９':
+			b.WriteRune(c - '０' + '0')
+		case c == '＋':
+			b.WriteRune('+')
+		case strings.ContainsRune(" \t　-－.()（）", c):
+		default:
+			b.WriteRune(c)
+		}
+	}
+	n := b.String()
+	if strings.HasPrefix(n, "00") {
+		n = "+" + n[2:]
+	}
+	if strings.HasPrefix(n, "+86") {
+		n = n[3:]
+	} else if len(n) == 13 && strings.HasPrefix(n, "861") {
+		n = n[2:]
+	}
+	return n
+}
+
+// Parse 规范化并校验号码
+func Parse(s string) (Number, error) {
+	number := Number{Input: s}
+	n := Normalize(s)
+	if n == "" {
+		return number, ErrEmpty
+	}
+	if strings.HasPrefix(n, "+") {
+		n = n[1:]
+		if !isDigits(n) {
+			return number, ErrInvalidChar
+		}
+		if len(n) < 8 || len(n) > 15 || n[0] == '0' {
+			return number, ErrInvalidLength
+		}
+		number.National = n
+		number.International = true
+		return number, nil
+	}
+	if !isDigits(n) {
+		return number, ErrInvalidChar
+	}
+	if len(n) != 11 {
+		return number, ErrInvalidLength
+	}
+	p, ok := lookupPrefix(n)
+	if !ok {
+		return number, ErrUnknownPrefix
+	}
+	number.National = n
+	number.Carrier = p.carrier
+	number.Virtual = p.virtual
+	return number, nil
+}
+
+// Validate 校验号码
+func Validate(s string) error {
+	_, err := Parse(s)
+	return err
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,50 @@
+package phone
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_Parse(t *testing.T) {
+	tests := []struct {
+		in       string
+		national string
+		carrier  Carrier
+		virtual  bool
+		intl     bool
+		err      error
+	}{
+		{"13800138000", "13800138000", CarrierChinaMobile, false, false, nil},
+		{"+86 138-0013-8000", "13800138000", CarrierChinaMobile, false, false, nil},
+		{"0086 18600000000", "18600000000", CarrierChinaUnicom, false, false, nil},
+		{"８６１３３００００００００", "13300000000", CarrierChinaTelecom, false, false, nil},
+		{"（１７０）１２３４５６７８", "17012345678", CarrierChinaTelecom, true, false, nil},
+		{"17051234567", "17051234567", CarrierChinaMobile, true, false, nil},
+		{"13491234567", "13491234567", CarrierChinaTelecom, false, false, nil},
+		{"+65 9123 4567", "6591234567", CarrierUnknown, false, true, nil},
+		{"0085212345678", "85212345678", CarrierUnknown, false, true, nil},
+		{"", "", CarrierUnknown, false, false, ErrEmpty},
+		{"1380013800", "", CarrierUnknown, false, false, ErrInvalidLength},
+		{"1380013800a", "", CarrierUnknown, false, false, ErrInvalidChar},
+		{"12000000000", "", CarrierUnknown, false, false, ErrUnknownPrefix},
+	}
+	for _, test := range tests {
+		n, err := Parse(test.in)
+		if err != test.err || n.National != test.national || n.Carrier != test.carrier || n.Virtual != test.virtual || n.International != test.intl {
+			t.Error("Parse failed", test.in, n, err)
+		}
+	}
+}
+
+func Test_ParseList(t *testing.T) {
+	numbers, rejected := ParseList([]string{"13800138000", "+8613800138000", "abc", "+6591234567", "138 0013 8000"})
+	if len(numbers) != 2 || numbers[0].String() != "13800138000" || numbers[1].String() != "+6591234567" {
+		t.Error("ParseList numbers failed", numbers)
+	}
+	if len(rejected) != 1 || rejected[0].Input != "abc" || rejected[0].Reason != ErrInvalidChar {
+		t.Error("ParseList rejected failed", rejected)
+	}
+	if !reflect.DeepEqual(Dedupe([]string{"13800138000", "+86 13800138000", "x", "x"}), []string{"13800138000", "x"}) {
+		t.Error("Dedupe failed")
+	}
+}
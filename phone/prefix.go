@@ -0,0 +1,37 @@
+// Package phone Copyright 2016 The GiterLab Authors. All rights reserved.
+package phone
+
+// prefix 号段信息
+type prefix struct {
+	carrier Carrier
+	virtual bool
+}
+
+// prefixes 国内手机号段, 先匹配4位号段, 再匹配3位号段
+var prefixes = map[string]prefix{}
+
+func init() {
+	add := func(carrier Carrier, virtual bool, segments ...string) {
+		for _, s := range segments {
+			prefixes[s] = prefix{carrier: carrier, virtual: virtual}
+		}
+	}
+	add(CarrierChinaMobile, false, "134", "135", "136", "137", "138", "139", "147", "148", "150", "151", "152", "157", "158", "159", "172", "178", "182", "183", "184", "187", "188", "195", "197", "198")
+	add(CarrierChinaUnicom, false, "130", "131", "132", "145", "146", "155", "156", "166", "175", "176", "185", "186", "196")
+	add(CarrierChinaTelecom, false, "133", "149", "153", "173", "177", "180", "181", "189", "190", "191", "193", "199", "1349")
+	add(CarrierChinaBroadnet, false, "192")
+
+	// 虚拟运营商号段
+	add(CarrierChinaMobile, true, "165", "1703", "1705", "1706")
+	add(CarrierChinaUnicom, true, "167", "171", "1704", "1707", "1708", "1709")
+	add(CarrierChinaTelecom, true, "162", "1700", "1701", "1702")
+}
+
+// lookupPrefix 查找号码所属号段
+func lookupPrefix(n string) (prefix, bool) {
+	if p, ok := prefixes[n[:4]]; ok {
+		return p, true
+	}
+	p, ok := prefixes[n[:3]]
+	return p, ok
+}
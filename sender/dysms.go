@@ -0,0 +1,52 @@
+// Package sender Copyright 2016 The GiterLab Authors. All rights reserved.
+package sender
+
+import (
+	"context"
+	"strings"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+)
+
+// DysmsSender 使用 dysms.SendSms 发送短信
+type DysmsSender struct {
+	Client *dysms.Client // 发送使用的账号, 为nil时使用 dysms.SetACLClient 设置的默认账号
+}
+
+// NewDysmsSender 创建一个 dysms 发送适配器, c 为nil时使用 dysms.SetACLClient 设置的默认账号
+func NewDysmsSender(c *dysms.Client) *DysmsSender {
+	return &DysmsSender{Client: c}
+}
+
+// Send 发送短信, ctx 只在发送前检查, 不能中断已发出的请求
+func (d *DysmsSender) Send(ctx context.Context, msg Message) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+	r := dysms.SendSms(msg.OutID, strings.Join(msg.PhoneNumbers, ","), msg.SignName, msg.TemplateCode, msg.TemplateParam)
+	r.Request.SetClient(d.Client)
+	resp, err := r.DoActionWithException()
+	if resp == nil {
+		return Result{}, &Error{Provider: "dysms", Err: err}
+	}
+	result := Result{RequestID: resp.GetRequestID(), BizID: resp.GetBizID()}
+	if err == nil && resp.GetCode() == "OK" {
+		return result, nil
+	}
+	return result, fromDysms(&resp.ErrorMessage, err)
+}
+
+// fromDysms 将 dysms.ErrorMessage 转换为 *Error
+func fromDysms(e *dysms.ErrorMessage, err error) *Error {
+	if err == nil {
+		err = e
+	}
+	return &Error{
+		Provider:  "dysms",
+		HTTPCode:  e.GetHTTPCode(),
+		RequestID: e.GetRequestID(),
+		Code:      e.GetCode(),
+		Message:   e.GetMessage(),
+		Err:       err,
+	}
+}
@@ -0,0 +1,61 @@
+// Package sender Copyright 2016 The GiterLab Authors. All rights reserved.
+//
+// sender 定义统一的短信发送接口, 并为旧版 sms 包和 dysms 包提供适配
+package sender
+
+import (
+	"context"
+	"strconv"
+)
+
+// Message 待发送的短信
+type Message struct {
+	PhoneNumbers  []string // 短信接收号码
+	SignName      string   // 短信签名
+	TemplateCode  string   // 短信模板CODE
+	TemplateParam string   // 短信模板变量替换JSON串
+	OutID         string   // 外部流水扩展字段
+}
+
+// Result 发送结果
+type Result struct {
+	RequestID string // 请求ID
+	BizID     string // 发送回执ID, 旧版接口为返回的 Model
+}
+
+// Sender 短信发送接口
+type Sender interface {
+	Send(ctx context.Context, msg Message) (Result, error)
+}
+
+// SenderFunc 将函数适配为 Sender, 可用于测试
+type SenderFunc func(ctx context.Context, msg Message) (Result, error)
+
+// Send 调用 f(ctx, msg)
+func (f SenderFunc) Send(ctx context.Context, msg Message) (Result, error) {
+	return f(ctx, msg)
+}
+
+// Error 统一的发送错误
+// 服务器返回错误时 Code 为服务器的错误码, 网络等其他错误时 Code 为空, 原始错误保存在 Err 中
+type Error struct {
+	Provider  string // 出错的实现, 如 dysms、sms
+	HTTPCode  int    // HTTP状态码, 未收到响应时为0
+	RequestID string // 请求ID
+	Code      string // 错误码
+	Message   string // 错误信息
+	Err       error  // 原始错误
+}
+
+// Error 错误描述
+func (e *Error) Error() string {
+	if e.Code == "" && e.Err != nil {
+		return e.Provider + ": " + e.Err.Error()
+	}
+	return e.Provider + ": " + e.Code + " " + e.Message + " (HTTP " + strconv.Itoa(e.HTTPCode) + ", RequestId " + e.RequestID + ")"
+}
+
+// Unwrap 原始错误
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,57 @@
+package sender
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+	"github.com/GiterLab/aliyun-sms-go-sdk/sms"
+)
+
+func Test_Sender(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch r.Form.Get("Action") {
+		case "SendSms":
+			if r.Form.Get("PhoneNumbers") == "13800000000" && r.Form.Get("OutId") == "out" {
+				w.Write([]byte(`{"RequestId":"R1","Code":"OK","Message":"OK","BizId":"B1"}`))
+				return
+			}
+			w.Write([]byte(`{"RequestId":"R2","Code":"isv.MOBILE_NUMBER_ILLEGAL","Message":"illegal"}`))
+		case "SingleSendSms":
+			if r.Form.Get("RecNum") == "13800000000" && r.Form.Get("OutId") == "out" {
+				w.Write([]byte(`{"RequestId":"R3","Model":"M3"}`))
+				return
+			}
+			w.WriteHeader(400)
+			w.Write([]byte(`{"RequestId":"R4","Code":"InvalidRecNum.Malformed","Message":"illegal"}`))
+		}
+	}))
+	defer ts.Close()
+	// 使用独立的账号配置发送, 不修改 dysms 的默认账号
+	d := dysms.NewClient("testId", "testSecret")
+	d.SetEndPoint(ts.URL + "/")
+	c := sms.New("testId", "testSecret")
+	c.SetEndPoint(ts.URL + "/")
+
+	senders := map[string]Sender{"dysms": NewDysmsSender(d), "sms": NewSmsSender(c)}
+	for name, s := range senders {
+		result, err := s.Send(context.Background(), Message{PhoneNumbers: []string{"13800000000"}, SignName: "sign", TemplateCode: "SMS_1", OutID: "out"})
+		if err != nil || result.RequestID == "" || result.BizID == "" {
+			t.Error(name, "Send failed", result, err)
+		}
+		_, err = s.Send(context.Background(), Message{PhoneNumbers: []string{"1"}, SignName: "sign", TemplateCode: "SMS_1"})
+		if e, ok := err.(*Error); !ok || e.Provider != name || e.Code == "" || e.RequestID == "" {
+			t.Error(name, "Send error failed", err)
+		}
+	}
+
+	var fake Sender = SenderFunc(func(ctx context.Context, msg Message) (Result, error) {
+		return Result{BizID: msg.OutID}, nil
+	})
+	if result, _ := fake.Send(context.Background(), Message{OutID: "x"}); result.BizID != "x" {
+		t.Error("SenderFunc failed", result)
+	}
+}
@@ -0,0 +1,55 @@
+// Package sender Copyright 2016 The GiterLab Authors. All rights reserved.
+package sender
+
+import (
+	"context"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/sms"
+)
+
+// SmsSender 使用旧版 sms.Client 的 SendOne/SendMulti 发送短信
+type SmsSender struct {
+	Client *sms.Client
+}
+
+// NewSmsSender 创建一个旧版 sms 发送适配器
+func NewSmsSender(c *sms.Client) *SmsSender {
+	return &SmsSender{Client: c}
+}
+
+// Send 发送短信, 一个号码时使用 SendOneWithOutID, 多个号码时使用 SendMultiWithOutID; msg.OutID 为空时使用 Client.Param 中的 OutID
+// ctx 只在发送前检查, 不能中断已发出的请求
+func (s *SmsSender) Send(ctx context.Context, msg Message) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+	outID := msg.OutID
+	if outID == "" {
+		outID = s.Client.Param.GetOutID()
+	}
+	var e *sms.ErrorMessage
+	var err error
+	if len(msg.PhoneNumbers) == 1 {
+		e, err = s.Client.SendOneWithOutID(outID, msg.PhoneNumbers[0], msg.SignName, msg.TemplateCode, msg.TemplateParam)
+	} else {
+		e, err = s.Client.SendMultiWithOutID(outID, msg.PhoneNumbers, msg.SignName, msg.TemplateCode, msg.TemplateParam)
+	}
+	if e == nil {
+		if err == nil {
+			return Result{}, nil
+		}
+		return Result{}, &Error{Provider: "sms", Err: err}
+	}
+	result := Result{RequestID: e.GetRequestID(), BizID: e.GetModel()}
+	if err == nil {
+		return result, nil
+	}
+	return result, &Error{
+		Provider:  "sms",
+		HTTPCode:  e.GetHTTPCode(),
+		RequestID: e.GetRequestID(),
+		Code:      e.GetCode(),
+		Message:   e.GetMessage(),
+		Err:       err,
+	}
+}
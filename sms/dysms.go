@@ -0,0 +1,64 @@
+// Package sms Copyright 2016 The GiterLab Authors. All rights reserved.
+package sms
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+)
+
+// DysmsErrorCodes dysms错误码到旧版接口错误码的映射, 未列出的错误码原样返回
+var DysmsErrorCodes = map[string]string{
+	"isv.MOBILE_NUMBER_ILLEGAL":       "InvalidRecNum.Malformed",
+	"isv.MOBILE_COUNT_OVER_LIMIT":     "InvalidRecNum.Malformed",
+	"isv.SMS_SIGNATURE_ILLEGAL":       "InvalidSignName.Malformed",
+	"isv.SMS_TEMPLATE_ILLEGAL":        "InvalidTemplateCode.MalFormed",
+	"isv.INVALID_JSON_PARAM":          "InvalidParamString.MalFormed",
+	"isv.TEMPLATE_MISSING_PARAMETERS": "InvalidParamStringTemplate.Malformed",
+	"isv.INVALID_PARAMETERS":          "InvalidParamString.MalFormed",
+}
+
+// dysmsMaxRecNum 转发到dysms时单次发送的号码数量上限
+const dysmsMaxRecNum = 1000
+
+// ForwardToDysms 将 SendOne/SendMulti 转发到 dysms 的 SendSms 接口, 使用当前客户端的accessid和accesskey
+// 返回的 dysms.Client 可用于修改服务地址; 调用方代码无需修改即可迁移到新接口
+func (c *Client) ForwardToDysms() *dysms.Client {
+	c.Dysms = dysms.NewClient(c.AccessID, c.AccessKey)
+	return c.Dysms
+}
+
+// sendDysms 通过 dysms 的 SendSms 接口发送短信, 并将结果转换为旧版接口的 ErrorMessage, Model 为 BizId
+func (c *Client) sendDysms(RecNum []string, signname, templatecode, ParamString, outID string) (e *ErrorMessage, err error) {
+	if len(RecNum) > dysmsMaxRecNum {
+		return nil, errors.New("number of RecNum should be less than 1000")
+	}
+	r := dysms.SendSms(outID, strings.Join(RecNum, ","), signname, templatecode, ParamString)
+	r.Request.SetClient(c.Dysms)
+	resp, err := r.DoActionWithException()
+	if resp == nil {
+		return nil, err
+	}
+	e = &ErrorMessage{
+		HTTPCode:  resp.GetHTTPCode(),
+		Model:     resp.BizID,
+		RequestID: resp.RequestID,
+	}
+	code := resp.GetCode()
+	if code == "OK" {
+		return e, nil
+	}
+	if legacy, ok := DysmsErrorCodes[code]; ok {
+		code = legacy
+	}
+	if code != "" {
+		e.Code = &code
+		e.Message = resp.Message
+		return e, errors.New(code)
+	}
+	if err == nil {
+		err = errors.New("unknown response from dysms")
+	}
+	return e, err
+}
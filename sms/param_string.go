@@ -0,0 +1,29 @@
+// Package sms Copyright 2016 The GiterLab Authors. All rights reserved.
+package sms
+
+import (
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
+)
+
+// MarshalParamString 将 map 或带 json 标签的结构体序列化为短信模板参数字符串, 同 dysms.MarshalTemplateParam
+func MarshalParamString(v interface{}) (string, error) {
+	return dysms.MarshalTemplateParam(v)
+}
+
+// SendOneObject 发送给一个手机号, 短信模板参数为 map 或带 json 标签的结构体
+func (c *Client) SendOneObject(RecNum, signname, templatecode string, paramObject interface{}) (e *ErrorMessage, err error) {
+	ParamString, err := MarshalParamString(paramObject)
+	if err != nil {
+		return nil, err
+	}
+	return c.SendOne(RecNum, signname, templatecode, ParamString)
+}
+
+// SendMultiObject 发送给多个手机号, 最多100个, 短信模板参数为 map 或带 json 标签的结构体
+func (c *Client) SendMultiObject(RecNum []string, signname, templatecode string, paramObject interface{}) (e *ErrorMessage, err error) {
+	ParamString, err := MarshalParamString(paramObject)
+	if err != nil {
+		return nil, err
+	}
+	return c.SendMulti(RecNum, signname, templatecode, ParamString)
+}
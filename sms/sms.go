@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/GiterLab/aliyun-sms-go-sdk/dysms"
 	"github.com/GiterLab/urllib"
 	"github.com/tobyzxj/uuid"
 )
@@ -253,7 +254,7 @@ func (e *ErrorMessage) Error() string {
 	return string(body)
 }
 
-// Client HTTP请求配置信息
+// Client HTTP请求配置信息, 配置完成后可在多个协程中共用
 type Client struct {
 	// SMS服务的地址，默认为（https://sms.aliyuncs.com）
 	EndPoint string
@@ -264,9 +265,11 @@ type Client struct {
 	// 连接池中每个连接的Socket超时，单位为秒，可以为int或float。默认值为30
 	SocketTimeout int
 
+	// 不为nil时 SendOne/SendMulti 转发到 dysms 的 SendSms 接口, 见 ForwardToDysms
+	Dysms *dysms.Client
+
 	// 其他参数
 	Param Param
-	param map[string]string
 }
 
 // SetEndPoint 设置短信服务器
@@ -292,30 +295,35 @@ func (c *Client) SetSocketTimeout(sockettimeout int) {
 	c.SocketTimeout = sockettimeout
 }
 
-func (c *Client) calcStringToSign() string {
-	c.param = make(map[string]string)
-	c.param["SignatureMethod"] = c.Param.GetSignatureMethod()
-	c.param["SignatureNonce"] = uuid.New()
-	// sync c.Param.SignatureNonce
-	c.Param.SetSignatureNonce(c.param["SignatureNonce"])
-	c.param["AccessKeyId"] = c.Param.GetAccessKeyID()
-	c.param["SignatureVersion"] = c.Param.GetSignatureVersion()
-	c.param["Timestamp"] = time.Now().UTC().Format(time.RFC3339)
-	// sync c.Param.Timestamp
-	c.Param.SetTimestamp(c.param["Timestamp"])
-	c.param["Format"] = c.Param.GetFormat()
-
-	c.param["Action"] = c.Param.GetAction()
-	c.param["Version"] = c.Param.GetVersion()
-	c.param["RegionId"] = c.Param.GetRegionID()
-	c.param["RecNum"] = c.Param.GetRecNum()
-	c.param["SignName"] = c.Param.GetSignName()
-	c.param["ParamString"] = c.Param.GetParamString()
-	c.param["TemplateCode"] = c.Param.GetTemplateCode()
-
-	strslice := make([]string, len(c.param))
+// values 生成请求参数, OutID 不为空时包含 OutId
+func (p *Param) values() map[string]string {
+	param := map[string]string{
+		"SignatureMethod":  p.GetSignatureMethod(),
+		"SignatureNonce":   p.GetSignatureNonce(),
+		"AccessKeyId":      p.GetAccessKeyID(),
+		"SignatureVersion": p.GetSignatureVersion(),
+		"Timestamp":        p.GetTimestamp(),
+		"Format":           p.GetFormat(),
+
+		"Action":       p.GetAction(),
+		"Version":      p.GetVersion(),
+		"RegionId":     p.GetRegionID(),
+		"RecNum":       p.GetRecNum(),
+		"SignName":     p.GetSignName(),
+		"ParamString":  p.GetParamString(),
+		"TemplateCode": p.GetTemplateCode(),
+	}
+	if p.GetOutID() != "" {
+		param["OutId"] = p.GetOutID()
+	}
+	return param
+}
+
+// stringToSign 计算签名字符串
+func stringToSign(param map[string]string) string {
+	strslice := make([]string, len(param))
 	i := 0
-	for k, v := range c.param {
+	for k, v := range param {
 		data := url.Values{}
 		data.Add(k, v)
 		strslice[i] = data.Encode()
@@ -326,22 +334,32 @@ func (c *Client) calcStringToSign() string {
 	return "POST&" + percentEncode("/") + "&" + percentEncode(strings.Join(strslice, "&"))
 }
 
-// SendOne 发送给一个手机号
-func (c *Client) SendOne(RecNum, signname, templatecode, ParamString string) (e *ErrorMessage, err error) {
+// newParam 复制客户端的参数并设置本次发送的参数, 不修改客户端, 因此同一个客户端可以并发发送
+func (c *Client) newParam(outID, RecNum, signname, templatecode, ParamString string) Param {
+	p := c.Param
+	p.SetOutID(outID)
+	p.SetSignName(signname)
+	p.SetTemplateCode(templatecode)
+	p.SetParamString(ParamString)
+	p.SetRecNum(RecNum)
+	p.SetSignatureNonce(uuid.New())
+	p.SetTimestamp(time.Now().UTC().Format(time.RFC3339))
+	return p
+}
+
+// send 签名并发送请求
+func (c *Client) send(p Param) (e *ErrorMessage, err error) {
 	var body []byte
 
 	e = &ErrorMessage{}
-	c.Param.SetSignName(signname)
-	c.Param.SetTemplateCode(templatecode)
-	c.Param.SetParamString(ParamString)
-	c.Param.SetRecNum(RecNum)
-	signature := signatureMethod(c.AccessKey, c.calcStringToSign())
+	param := p.values()
+	signature := signatureMethod(c.AccessKey, stringToSign(param))
 
 	req := urllib.Post(c.EndPoint)
 	if HTTPDebugEnable {
 		req.Debug(true)
 	}
-	for k, v := range c.param {
+	for k, v := range param {
 		req.Param(k, v)
 	}
 	req.Param("Signature", signature)
@@ -380,58 +398,33 @@ func (c *Client) SendOne(RecNum, signname, templatecode, ParamString string) (e
 	return e, nil
 }
 
-// SendMulti 发送给多个手机号, 最多100个
-func (c *Client) SendMulti(RecNum []string, signname, templatecode, ParamString string) (e *ErrorMessage, err error) {
-	var body []byte
+// SendOne 发送给一个手机号, 可并发调用, 使用 Param 中的 OutID
+func (c *Client) SendOne(RecNum, signname, templatecode, ParamString string) (e *ErrorMessage, err error) {
+	return c.SendOneWithOutID(c.Param.GetOutID(), RecNum, signname, templatecode, ParamString)
+}
 
-	e = &ErrorMessage{}
-	if len(RecNum) > 100 {
-		return nil, errors.New("number of RecNum should be less than 100")
+// SendOneWithOutID 发送给一个手机号, 使用本次调用指定的外部流水扩展字段, 可并发调用
+func (c *Client) SendOneWithOutID(outID, RecNum, signname, templatecode, ParamString string) (e *ErrorMessage, err error) {
+	if c.Dysms != nil {
+		return c.sendDysms([]string{RecNum}, signname, templatecode, ParamString, outID)
 	}
-	c.Param.SetSignName(signname)
-	c.Param.SetTemplateCode(templatecode)
-	c.Param.SetParamString(ParamString)
-	c.Param.SetRecNum(strings.Join(RecNum, ","))
-	signature := signatureMethod(c.AccessKey, c.calcStringToSign())
+	return c.send(c.newParam(outID, RecNum, signname, templatecode, ParamString))
+}
 
-	req := urllib.Post(c.EndPoint)
-	for k, v := range c.param {
-		req.Param(k, v)
-	}
-	req.Param("Signature", signature)
-	resp, err := req.Response()
-	if err != nil {
-		return nil, err
-	}
-	if resp.Body == nil {
-		return nil, nil
-	}
-	defer resp.Body.Close()
-	if resp.Header.Get("Content-Encoding") == "gzip" {
-		reader, errGzip := gzip.NewReader(resp.Body)
-		if errGzip != nil {
-			return nil, errGzip
-		}
-		body, err = ioutil.ReadAll(reader)
-	} else {
-		body, err = ioutil.ReadAll(resp.Body)
-	}
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(body, e)
-	e.SetHTTPCode(resp.StatusCode)
-	if HTTPDebugEnable {
-		fmt.Println("C-->S:", req.DumpRequestString())
-		fmt.Println("S-->C:", e.Error())
-	}
-	if err != nil {
-		return e, err
+// SendMulti 发送给多个手机号, 最多100个, 可并发调用, 使用 Param 中的 OutID
+func (c *Client) SendMulti(RecNum []string, signname, templatecode, ParamString string) (e *ErrorMessage, err error) {
+	return c.SendMultiWithOutID(c.Param.GetOutID(), RecNum, signname, templatecode, ParamString)
+}
+
+// SendMultiWithOutID 发送给多个手机号, 最多100个, 使用本次调用指定的外部流水扩展字段, 可并发调用
+func (c *Client) SendMultiWithOutID(outID string, RecNum []string, signname, templatecode, ParamString string) (e *ErrorMessage, err error) {
+	if c.Dysms != nil {
+		return c.sendDysms(RecNum, signname, templatecode, ParamString, outID)
 	}
-	if e.GetCode() != "" {
-		return e, errors.New(e.GetCode())
+	if len(RecNum) > 100 {
+		return nil, errors.New("number of RecNum should be less than 100")
 	}
-	return e, nil
+	return c.send(c.newParam(outID, strings.Join(RecNum, ","), signname, templatecode, ParamString))
 }
 
 // New 创建一个短信发送客户端
@@ -1,6 +1,9 @@
 package sms
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
 )
@@ -31,9 +34,95 @@ func Test_stringToSign(t *testing.T) {
 	c.Param.SetSignatureVersion("1.0")
 	c.Param.SetSignatureNonce("9e030f6b-03a2-40f0-a6ba-157d44532fd0")
 	c.Param.SetRegionID("cn-hangzhou")
-	stringToSign := c.calcStringToSign()
-	stringToSignResult := `POST&%2F&AccessKeyId%3Dtestid%26Action%3DSingleSendSms%26Format%3DXML%26ParamString%3D%257B%2522name%2522%253A%2522d%2522%252C%2522name1%2522%253A%2522d%2522%257D%26RecNum%3D13098765432%26RegionId%3Dcn-hangzhou%26SignName%3D%25E6%25A0%2587%25E7%25AD%25BE%25E6%25B5%258B%25E8%25AF%2595%26SignatureMethod%3DHMAC-SHA1%26SignatureNonce%3D` + url.QueryEscape(url.QueryEscape(c.Param.GetSignatureNonce())) + `%26SignatureVersion%3D1.0%26TemplateCode%3DSMS_1650053%26Timestamp%3D` + url.QueryEscape(url.QueryEscape(c.Param.GetTimestamp())) + `%26Version%3D2016-09-27`
-	if stringToSign != stringToSignResult {
-		t.Error("calcStringToSign failed")
+	p := c.newParam("", "13098765432", "标签测试", "SMS_1650053", `{"name":"d","name1":"d"}`)
+	stringToSignResult := `POST&%2F&AccessKeyId%3Dtestid%26Action%3DSingleSendSms%26Format%3DXML%26ParamString%3D%257B%2522name%2522%253A%2522d%2522%252C%2522name1%2522%253A%2522d%2522%257D%26RecNum%3D13098765432%26RegionId%3Dcn-hangzhou%26SignName%3D%25E6%25A0%2587%25E7%25AD%25BE%25E6%25B5%258B%25E8%25AF%2595%26SignatureMethod%3DHMAC-SHA1%26SignatureNonce%3D` + url.QueryEscape(url.QueryEscape(p.GetSignatureNonce())) + `%26SignatureVersion%3D1.0%26TemplateCode%3DSMS_1650053%26Timestamp%3D` + url.QueryEscape(url.QueryEscape(p.GetTimestamp())) + `%26Version%3D2016-09-27`
+	if stringToSign(p.values()) != stringToSignResult {
+		t.Error("stringToSign failed")
+	}
+	if c.Param.GetSignatureNonce() != "9e030f6b-03a2-40f0-a6ba-157d44532fd0" || c.Param.GetTimestamp() != "2016-10-20T05:37:52Z" {
+		t.Error("newParam should not modify client")
+	}
+}
+
+func Test_MarshalParamString(t *testing.T) {
+	s, err := MarshalParamString(struct {
+		Company string `json:"company"`
+		URL     string `json:"url"`
+	}{"duoxieyun", "https://example.com/?a=1&b=2"})
+	if err != nil || s != `{"company":"duoxieyun","url":"https://example.com/?a=1&b=2"}` {
+		t.Error("MarshalParamString failed", s, err)
+	}
+	if _, err = MarshalParamString(map[string]int{"count": 1}); err == nil {
+		t.Error("MarshalParamString should reject non-string value")
+	}
+}
+
+func Test_ForwardToDysms(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("Action") != "SendSms" || q.Get("AccessKeyId") != "testid" || q.Get("TemplateParam") != `{"code":"1234"}` {
+			t.Error("ForwardToDysms request failed", q)
+		}
+		if q.Get("PhoneNumbers") == "13000000000,13000000001" {
+			w.Write([]byte(`{"RequestId":"R1","Code":"OK","Message":"OK","BizId":"B1"}`))
+			return
+		}
+		w.Write([]byte(`{"RequestId":"R2","Code":"isv.MOBILE_NUMBER_ILLEGAL","Message":"illegal"}`))
+	}))
+	defer ts.Close()
+
+	c := New("testid", "testsecret")
+	c.ForwardToDysms().SetEndPoint(ts.URL + "/")
+	e, err := c.SendMulti([]string{"13000000000", "13000000001"}, "sign", "SMS_1", `{"code":"1234"}`)
+	if err != nil || e.GetModel() != "B1" || e.GetRequestID() != "R1" || e.GetCode() != "" {
+		t.Error("ForwardToDysms failed", e, err)
+	}
+	e, err = c.SendOne("1", "sign", "SMS_1", `{"code":"1234"}`)
+	if err == nil || e.GetCode() != "InvalidRecNum.Malformed" || e.GetMessage() != "illegal" {
+		t.Error("ForwardToDysms error failed", e, err)
+	}
+}
+
+func Test_SendConcurrent(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		param := make(map[string]string)
+		for k := range r.PostForm {
+			if k != "Signature" {
+				param[k] = r.PostForm.Get(k)
+			}
+		}
+		// 每个请求的签名都必须与其自身的参数一致
+		if signatureMethod("testsecret", stringToSign(param)) != r.PostForm.Get("Signature") {
+			w.WriteHeader(400)
+			w.Write([]byte(`{"Code":"SignatureDoesNotMatch"}`))
+			return
+		}
+		if param["OutId"] != "out-"+param["RecNum"] || param["TemplateCode"] != "SMS_"+param["RecNum"] {
+			w.WriteHeader(400)
+			w.Write([]byte(`{"Code":"InvalidParam"}`))
+			return
+		}
+		w.Write([]byte(`{"RequestId":"R","Model":"` + param["RecNum"] + `"}`))
+	}))
+	defer ts.Close()
+
+	c := New("testid", "testsecret")
+	c.SetEndPoint(ts.URL + "/")
+	done := make(chan error)
+	for i := 0; i < 20; i++ {
+		go func(i int) {
+			recNum := fmt.Sprintf("130000000%02d", i)
+			e, err := c.SendOneWithOutID("out-"+recNum, recNum, "sign", "SMS_"+recNum, `{"code":"1234"}`)
+			if err == nil && e.GetModel() != recNum {
+				err = fmt.Errorf("unexpected model %s for %s", e.GetModel(), recNum)
+			}
+			done <- err
+		}(i)
+	}
+	for i := 0; i < 20; i++ {
+		if err := <-done; err != nil {
+			t.Error("SendOne concurrent failed", err)
+		}
 	}
 }